@@ -1,20 +1,29 @@
 package goterm
 
 import (
-	"fmt"
+	"strings"
 	"time"
 )
 
 // Animation 提供终端动画效果
 type Animation struct {
-	cursor *Cursor
+	term *Terminal // 输出动画的终端，为空时使用默认终端
 }
 
 // NewAnimation 创建一个新的Animation实例
 func NewAnimation() *Animation {
-	return &Animation{
-		cursor: NewCursor(),
-	}
+	return &Animation{}
+}
+
+// SetTerminal 设置输出动画的终端（为空时使用默认终端）
+func (a *Animation) SetTerminal(term *Terminal) *Animation {
+	a.term = term
+	return a
+}
+
+// terminal 返回输出动画的终端
+func (a *Animation) terminal() *Terminal {
+	return terminalOr(a.term)
 }
 
 // 增强型动画样式常量
@@ -29,7 +38,7 @@ const (
 type Typewriter struct {
 	text      string
 	delay     time.Duration
-	cursor    *Cursor
+	animation *Animation
 	isRunning bool
 	mode      int
 }
@@ -39,7 +48,7 @@ func (a *Animation) NewTypewriter(text string) *Typewriter {
 	return &Typewriter{
 		text:      text,
 		delay:     50 * time.Millisecond,
-		cursor:    a.cursor,
+		animation: a,
 		isRunning: false,
 		mode:      TypewriterModeNormal,
 	}
@@ -64,7 +73,8 @@ func (t *Typewriter) Play() {
 	}
 
 	t.isRunning = true
-	t.cursor.HideCursor()
+	term := t.animation.terminal()
+	term.HideCursor()
 
 	switch t.mode {
 	case TypewriterModeNormal:
@@ -78,43 +88,44 @@ func (t *Typewriter) Play() {
 	}
 
 	t.isRunning = false
-	t.cursor.ShowCursor()
-	fmt.Println() // 打字效果结束后换行
+	term.ShowCursor()
+	term.Println() // 打字效果结束后换行
 }
 
 // playNormal 普通打字效果
 func (t *Typewriter) playNormal() {
-	runes := []rune(t.text)
-	for i := 0; i < len(runes); i++ {
-		fmt.Print(string(runes[i]))
+	term := t.animation.terminal()
+	chars := graphemes(t.text)
+	for i := 0; i < len(chars); i++ {
+		term.Print(chars[i])
 		time.Sleep(t.delay)
 	}
 }
 
 // playFadeIn 渐入效果
 func (t *Typewriter) playFadeIn() {
-	runes := []rune(t.text)
-	for i := 0; i < len(runes); i++ {
-		// 显示到当前位置的文本
-		t.cursor.ClearLine()
-		fmt.Print("\r")
-		fmt.Print(string(runes[:i+1]))
+	screen := t.animation.terminal().NewScreenRenderer()
+	chars := graphemes(t.text)
+	for i := 0; i < len(chars); i++ {
+		// 显示到当前位置的文本（只输出新增的字符）
+		screen.RenderLines(strings.Join(chars[:i+1], ""))
 		time.Sleep(t.delay)
 	}
 }
 
 // playBlinking 闪烁效果
 func (t *Typewriter) playBlinking() {
-	runes := []rune(t.text)
-	for i := 0; i < len(runes); i++ {
+	term := t.animation.terminal()
+	chars := graphemes(t.text)
+	for i := 0; i < len(chars); i++ {
 		// 先显示字符
-		fmt.Print(string(runes[i]))
+		term.Print(chars[i])
 
 		// 如果不是最后一个字符，添加闪烁光标
-		if i < len(runes)-1 {
-			fmt.Print("▋")
+		if i < len(chars)-1 {
+			term.Print("▋")
 			time.Sleep(t.delay / 2)
-			fmt.Print("\b \b") // 删除光标
+			term.Print("\b \b") // 删除光标
 			time.Sleep(t.delay / 2)
 		}
 	}
@@ -132,21 +143,24 @@ func (a *Animation) RainbowText(text string, cycles int, delay time.Duration) {
 	}
 
 	reset := "\033[0m"
-	runes := []rune(text)
+	chars := graphemes(text)
 
-	a.cursor.HideCursor()
+	term := a.terminal()
+	term.HideCursor()
 
+	screen := term.NewScreenRenderer()
 	for cycle := 0; cycle < cycles; cycle++ {
 		for colorIndex := 0; colorIndex < len(colors); colorIndex++ {
-			fmt.Print("\r")
-			for i := 0; i < len(runes); i++ {
+			var frame strings.Builder
+			for i := 0; i < len(chars); i++ {
 				currentColor := colors[(colorIndex+i)%len(colors)]
-				fmt.Print(currentColor + string(runes[i]) + reset)
+				frame.WriteString(currentColor + chars[i] + reset)
 			}
+			screen.RenderLines(frame.String())
 			time.Sleep(delay)
 		}
 	}
+	screen.Finish()
 
-	a.cursor.ShowCursor()
-	fmt.Println()
+	term.ShowCursor()
 }
@@ -0,0 +1,138 @@
+package goterm
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AdaptiveColor 表示随终端背景变化的颜色：浅色背景使用 Light，深色背景使用 Dark
+type AdaptiveColor struct {
+	Light Color // 浅色背景下使用的颜色
+	Dark  Color // 深色背景下使用的颜色
+}
+
+// code 返回自适应颜色对应的样式代码
+func (c AdaptiveColor) code(kind string) styleCode {
+	return styleCode{light: c.Light.code(kind), dark: c.Dark.code(kind)}
+}
+
+// BackgroundQueryTimeout 查询终端背景色时等待终端响应的最长时间
+var BackgroundQueryTimeout = 100 * time.Millisecond
+
+// DetectBackgroundColor 检测终端的背景色：
+// 先通过 OSC 11 查询终端，终端不响应时使用 COLORFGBG 环境变量，检测失败时返回 false
+func DetectBackgroundColor() (Color, bool) {
+	if c, ok := queryBackgroundColor(BackgroundQueryTimeout); ok {
+		return c, true
+	}
+	return envBackgroundColor()
+}
+
+// 终端背景色，同一进程内所有输出到终端的渲染器共享检测结果
+var (
+	terminalBackgroundOnce  sync.Once
+	terminalBackground      Color
+	terminalBackgroundFound bool
+)
+
+// cachedBackgroundColor 返回终端的背景色，只在第一次调用时检测
+func cachedBackgroundColor() (Color, bool) {
+	terminalBackgroundOnce.Do(func() {
+		terminalBackground, terminalBackgroundFound = DetectBackgroundColor()
+	})
+	return terminalBackground, terminalBackgroundFound
+}
+
+// envBackgroundColor 根据 COLORFGBG 环境变量（格式为 "前景色;背景色"）获取背景色
+func envBackgroundColor() (Color, bool) {
+	value := os.Getenv("COLORFGBG")
+	if value == "" {
+		return Color{}, false
+	}
+
+	fields := strings.Split(value, ";")
+	index, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || index < 0 || index > 15 {
+		return Color{}, false
+	}
+	return ANSIColor(index), true
+}
+
+// queryBackgroundColor 通过 OSC 11 向终端查询背景色。
+// 查询之后紧跟一个 DA1 查询，几乎所有终端都会响应 DA1，
+// 因此不支持 OSC 11 的终端不需要等待超时
+func queryBackgroundColor(timeout time.Duration) (Color, bool) {
+	if os.Getenv("TERM") == "dumb" {
+		return Color{}, false
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return Color{}, false
+	}
+	defer tty.Close()
+
+	response, err := NewTerminal(tty).SetInput(tty).query("\033]11;?\033\\\033[c", timeout, deviceAttributesReceived)
+	if err != nil {
+		return Color{}, false
+	}
+	return parseOSCColor(response)
+}
+
+// readTerminalResponse 从终端读取响应，直到 done 返回 true 或超时
+func readTerminalResponse(tty *os.File, timeout time.Duration, done func(string) bool) (string, bool) {
+	deadline := time.Now().Add(timeout)
+	if err := tty.SetReadDeadline(deadline); err != nil {
+		// 不支持读取超时的终端无法安全地等待响应
+		return "", false
+	}
+
+	var response strings.Builder
+	buffer := make([]byte, 64)
+	for {
+		n, err := tty.Read(buffer)
+		response.Write(buffer[:n])
+		if done(response.String()) {
+			return response.String(), true
+		}
+		if err != nil {
+			return response.String(), false
+		}
+	}
+}
+
+// parseOSCColor 解析终端响应中的 OSC 颜色，格式为 "ESC ] 11 ; rgb:RRRR/GGGG/BBBB"，
+// 每个分量为 1 到 4 位十六进制数
+func parseOSCColor(response string) (Color, bool) {
+	start := strings.Index(response, "rgb:")
+	if start < 0 {
+		return Color{}, false
+	}
+	value := response[start+len("rgb:"):]
+	if end := strings.IndexAny(value, "\033\a"); end >= 0 {
+		value = value[:end]
+	}
+
+	parts := strings.Split(value, "/")
+	if len(parts) != 3 {
+		return Color{}, false
+	}
+
+	var rgb [3]int
+	for i, part := range parts {
+		if len(part) == 0 || len(part) > 4 {
+			return Color{}, false
+		}
+		v, err := strconv.ParseUint(part, 16, 16)
+		if err != nil {
+			return Color{}, false
+		}
+		// 按分量的位数缩放到 0-255
+		max := uint64(1)<<(4*len(part)) - 1
+		rgb[i] = int(v * 255 / max)
+	}
+	return NewColor(rgb[0], rgb[1], rgb[2]), true
+}
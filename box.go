@@ -0,0 +1,353 @@
+package goterm
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// BorderSet 表示绘制边框使用的字符
+type BorderSet struct {
+	Top         string // 上边
+	Bottom      string // 下边
+	Left        string // 左边
+	Right       string // 右边
+	TopLeft     string // 左上角
+	TopRight    string // 右上角
+	BottomLeft  string // 左下角
+	BottomRight string // 右下角
+}
+
+// 内置的边框字符集
+var (
+	// BorderSingle 单线边框
+	BorderSingle = BorderSet{Top: "─", Bottom: "─", Left: "│", Right: "│", TopLeft: "┌", TopRight: "┐", BottomLeft: "└", BottomRight: "┘"}
+	// BorderDouble 双线边框
+	BorderDouble = BorderSet{Top: "═", Bottom: "═", Left: "║", Right: "║", TopLeft: "╔", TopRight: "╗", BottomLeft: "╚", BottomRight: "╝"}
+	// BorderRounded 圆角边框
+	BorderRounded = BorderSet{Top: "─", Bottom: "─", Left: "│", Right: "│", TopLeft: "╭", TopRight: "╮", BottomLeft: "╰", BottomRight: "╯"}
+	// BorderThick 粗线边框
+	BorderThick = BorderSet{Top: "━", Bottom: "━", Left: "┃", Right: "┃", TopLeft: "┏", TopRight: "┓", BottomLeft: "┗", BottomRight: "┛"}
+	// BorderASCII 只使用 ASCII 字符的边框，适用于不支持制表符的终端
+	BorderASCII = BorderSet{Top: "-", Bottom: "-", Left: "|", Right: "|", TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+"}
+	// BorderNone 不绘制边框（标题和页脚单独占一行）
+	BorderNone = BorderSet{}
+)
+
+// Spacing 表示上、右、下、左四个方向的空白
+type Spacing struct {
+	Top, Right, Bottom, Left int
+}
+
+// newSpacing 按照 CSS 的简写规则创建空白：一个值用于四个方向，两个值依次为上下和左右，
+// 三个值依次为上、左右和下，四个值依次为上、右、下、左。负数视为 0
+func newSpacing(values ...int) Spacing {
+	switch len(values) {
+	case 0:
+		return Spacing{}
+	case 1:
+		return Spacing{values[0], values[0], values[0], values[0]}.clamp()
+	case 2:
+		return Spacing{values[0], values[1], values[0], values[1]}.clamp()
+	case 3:
+		return Spacing{values[0], values[1], values[2], values[1]}.clamp()
+	default:
+		return Spacing{values[0], values[1], values[2], values[3]}.clamp()
+	}
+}
+
+// clamp 返回把负数替换为 0 的空白
+func (s Spacing) clamp() Spacing {
+	for _, v := range []*int{&s.Top, &s.Right, &s.Bottom, &s.Left} {
+		if *v < 0 {
+			*v = 0
+		}
+	}
+	return s
+}
+
+// Box 表示带有边框的方框，可以包含任意多行、带有样式的内容
+type Box struct {
+	Content     string    // 内容，可以包含换行符和样式
+	Border      BorderSet // 边框字符
+	BorderStyle *Style    // 边框样式（为空时使用主题中的边框样式）
+	Title       string    // 嵌入在上边框中的标题
+	TitleAlign  Alignment // 标题的对齐方式
+	TitleStyle  *Style    // 标题样式（为空时使用主题中的表头样式）
+	Footer      string    // 嵌入在下边框中的页脚
+	FooterAlign Alignment // 页脚的对齐方式
+	FooterStyle *Style    // 页脚样式（为空时使用主题中的弱化样式）
+	Align       Alignment // 内容的对齐方式
+	Padding     Spacing   // 内边距（边框和内容之间的空白）
+	Margin      Spacing   // 外边距（边框外面的空白）
+	Width       int       // 总宽度（包括边框和内边距，不包括外边距），为 0 时根据内容自动计算
+	Theme       *Theme    // 主题（为空时使用全局主题）
+}
+
+// NewBox 创建单线边框、左右内边距为 1 的方框
+func NewBox(content string) *Box {
+	return &Box{
+		Content: content,
+		Border:  BorderSingle,
+		Padding: Spacing{Left: 1, Right: 1},
+	}
+}
+
+// NewPanel 创建带有标题的圆角面板
+func NewPanel(title, content string) *Box {
+	return NewBox(content).SetBorder(BorderRounded).SetTitle(title)
+}
+
+// SetContent 设置内容
+func (b *Box) SetContent(content string) *Box {
+	b.Content = content
+	return b
+}
+
+// SetBorder 设置边框字符
+func (b *Box) SetBorder(border BorderSet) *Box {
+	b.Border = border
+	return b
+}
+
+// SetBorderStyle 设置边框样式
+func (b *Box) SetBorderStyle(style *Style) *Box {
+	b.BorderStyle = style
+	return b
+}
+
+// SetTitle 设置嵌入在上边框中的标题
+func (b *Box) SetTitle(title string) *Box {
+	b.Title = title
+	return b
+}
+
+// SetTitleAlign 设置标题的对齐方式
+func (b *Box) SetTitleAlign(align Alignment) *Box {
+	b.TitleAlign = align
+	return b
+}
+
+// SetTitleStyle 设置标题样式
+func (b *Box) SetTitleStyle(style *Style) *Box {
+	b.TitleStyle = style
+	return b
+}
+
+// SetFooter 设置嵌入在下边框中的页脚
+func (b *Box) SetFooter(footer string) *Box {
+	b.Footer = footer
+	return b
+}
+
+// SetFooterAlign 设置页脚的对齐方式
+func (b *Box) SetFooterAlign(align Alignment) *Box {
+	b.FooterAlign = align
+	return b
+}
+
+// SetFooterStyle 设置页脚样式
+func (b *Box) SetFooterStyle(style *Style) *Box {
+	b.FooterStyle = style
+	return b
+}
+
+// SetAlign 设置内容的对齐方式
+func (b *Box) SetAlign(align Alignment) *Box {
+	b.Align = align
+	return b
+}
+
+// SetPadding 设置内边距，参数按照 CSS 的简写规则：
+// 一个值用于四个方向，两个值依次为上下和左右，四个值依次为上、右、下、左
+func (b *Box) SetPadding(values ...int) *Box {
+	b.Padding = newSpacing(values...)
+	return b
+}
+
+// SetMargin 设置外边距，参数规则与 SetPadding 相同
+func (b *Box) SetMargin(values ...int) *Box {
+	b.Margin = newSpacing(values...)
+	return b
+}
+
+// SetWidth 设置总宽度（包括边框和内边距），内容超过宽度时换行；为 0 时根据内容自动计算
+func (b *Box) SetWidth(width int) *Box {
+	b.Width = width
+	return b
+}
+
+// SetTheme 设置方框使用的主题
+func (b *Box) SetTheme(theme *Theme) *Box {
+	b.Theme = theme
+	return b
+}
+
+// String 返回方框的字符串表示（使用默认渲染器的颜色能力）
+func (b *Box) String() string {
+	return b.render(DefaultRenderer())
+}
+
+// Print 打印方框
+func (b *Box) Print() {
+	b.Fprint(Output)
+}
+
+// Fprint 打印方框到指定的 writer（根据该 writer 决定颜色能力）
+func (b *Box) Fprint(w io.Writer) {
+	fmt.Fprintln(w, b.render(rendererFor(w)))
+}
+
+// render 使用指定的渲染器绘制方框
+func (b *Box) render(r *Renderer) string {
+	theme := themeOr(b.Theme)
+	borderStyle := styleOr(b.BorderStyle, theme.Border)
+	titleStyle := styleOr(b.TitleStyle, theme.Header)
+	footerStyle := styleOr(b.FooterStyle, theme.Muted)
+
+	left, right := b.Border.Left, b.Border.Right
+	pad, outer := b.Padding.clamp(), b.Margin.clamp()
+	frame := StringWidth(left) + StringWidth(right) + pad.Left + pad.Right
+
+	// 计算内容区域的宽度，固定宽度时对超出的行换行。
+	// 内容区域至少可以容纳一个宽字符，因此宽度很小时方框会超出设置的宽度
+	lines := styledLines(b.Content)
+	inner := 0
+	if b.Width > 0 {
+		inner = b.Width - frame
+		if inner < 2 {
+			inner = 2
+		}
+		opts := wrapOptions{width: inner, wordBreak: WordBreakHard}
+		if b.Align == AlignJustify {
+			opts.align = AlignJustify
+		}
+		var wrapped []string
+		for _, line := range lines {
+			if StringWidth(line) <= inner {
+				wrapped = append(wrapped, line)
+				continue
+			}
+			wrapped = append(wrapped, wrapText(line, opts)...)
+		}
+		lines = wrapped
+	} else {
+		for _, line := range lines {
+			if w := StringWidth(line); w > inner {
+				inner = w
+			}
+		}
+		// 保证标题和页脚（两侧各有一个空格）可以完整显示
+		for _, label := range []string{b.Title, b.Footer} {
+			if label == "" {
+				continue
+			}
+			if w := StringWidth(label) + 2 - pad.Left - pad.Right; w > inner {
+				inner = w
+			}
+		}
+	}
+	span := inner + pad.Left + pad.Right // 左右边框之间的宽度
+
+	var out []string
+	margin := strings.Repeat(" ", outer.Left)
+	trailing := strings.Repeat(" ", outer.Right)
+	add := func(line string) {
+		out = append(out, margin+line+trailing)
+	}
+	for i := 0; i < outer.Top; i++ {
+		out = append(out, "")
+	}
+
+	// 上边框和标题
+	if b.Border.Top != "" || b.Title != "" {
+		add(b.borderLine(r, b.Border.TopLeft, b.Border.Top, b.Border.TopRight, b.Title, b.TitleAlign, span, borderStyle, titleStyle))
+	}
+
+	// 内容和上下内边距
+	side := func(text string) string {
+		return r.Sprint(borderStyle, left) + text + r.Sprint(borderStyle, right)
+	}
+	blank := strings.Repeat(" ", span)
+	for i := 0; i < pad.Top; i++ {
+		add(side(blank))
+	}
+	for _, line := range lines {
+		content := alignText(line, inner-StringWidth(line), b.Align)
+		add(side(strings.Repeat(" ", pad.Left) + content + strings.Repeat(" ", pad.Right)))
+	}
+	for i := 0; i < pad.Bottom; i++ {
+		add(side(blank))
+	}
+
+	// 下边框和页脚
+	if b.Border.Bottom != "" || b.Footer != "" {
+		add(b.borderLine(r, b.Border.BottomLeft, b.Border.Bottom, b.Border.BottomRight, b.Footer, b.FooterAlign, span, borderStyle, footerStyle))
+	}
+
+	for i := 0; i < outer.Bottom; i++ {
+		out = append(out, "")
+	}
+	return strings.Join(out, "\n")
+}
+
+// borderLine 绘制上边框或下边框，label 不为空时按照对齐方式嵌入在边框中
+func (b *Box) borderLine(r *Renderer, left, fill, right, label string, align Alignment, span int, borderStyle, labelStyle *Style) string {
+	if fill == "" {
+		fill = " "
+	}
+	if label == "" {
+		return r.Sprint(borderStyle, left+strings.Repeat(fill, span)+right)
+	}
+
+	// 标题两侧各留一个空格，宽度不够时截断标题
+	width := StringWidth(label)
+	if width+2 > span {
+		if span < 5 {
+			return r.Sprint(borderStyle, left+strings.Repeat(fill, span)+right)
+		}
+		label, width = truncateText(label, span-2)
+	}
+	free := span - width - 2
+
+	before := 0
+	switch align {
+	case AlignCenter:
+		before = free / 2
+	case AlignRight:
+		before = free - 1
+	default:
+		before = 1
+	}
+	if before < 0 || before > free {
+		before = free / 2
+	}
+
+	return r.Sprint(borderStyle, left+strings.Repeat(fill, before)+" ") +
+		r.Sprint(labelStyle, label) +
+		r.Sprint(borderStyle, " "+strings.Repeat(fill, free-before)+right)
+}
+
+// styledLines 将文本按换行符拆分为行，样式和超链接跨行时在行尾关闭，并在下一行的开头重新开启，
+// 使每一行都可以单独输出
+func styledLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	style, link := "", ""
+	for i, line := range lines {
+		var out strings.Builder
+		out.WriteString(style)
+		if link != "" {
+			out.WriteString(hyperlinkStart + link + hyperlinkEnd)
+		}
+		out.WriteString(line)
+		style, link = scanEscapes(style, link, line)
+		if style != "" {
+			out.WriteString(Reset)
+		}
+		if link != "" {
+			out.WriteString(hyperlinkStart + hyperlinkEnd)
+		}
+		lines[i] = out.String()
+	}
+	return lines
+}
@@ -0,0 +1,27 @@
+package goterm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBoxEdgeCases(t *testing.T) {
+	r := NewRenderer(&bytes.Buffer{})
+	tests := []struct {
+		name string
+		box  *Box
+		want []string
+	}{
+		{"negative padding", NewBox("ab").SetPadding(-3), []string{"┌──┐", "│ab│", "└──┘"}},
+		{"negative margin", NewBox("ab").SetMargin(-1, -2), []string{"┌────┐", "│ ab │", "└────┘"}},
+		{"wide rune in narrow box", NewBox("中文").SetWidth(5), []string{"┌────┐", "│ 中 │", "│ 文 │", "└────┘"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := tt.box.render(r), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("box:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package goterm
 
 import (
 	"fmt"
+	"io"
 	"math"
 	"sort"
 	"strings"
@@ -21,11 +22,13 @@ type BarChart struct {
 	Data        map[string]int // 数据（标签 -> 值）
 	Width       int            // 条形图宽度
 	MaxBarWidth int            // 最大条形宽度
-	BarStyle    *Style         // 条形样式
+	BarStyle    *Style         // 条形样式（为空时使用主题中的强调色）
 	LabelStyle  *Style         // 标签样式
-	ValueStyle  *Style         // 值样式
-	TitleStyle  *Style         // 标题样式
+	ValueStyle  *Style         // 值样式（为空时使用主题中的数值样式）
+	TitleStyle  *Style         // 标题样式（为空时使用主题中的标题样式）
 	BarChar     string         // 用于绘制条形的字符
+	Theme       *Theme         // 主题（为空时使用全局主题）
+	Gradient    *ColorGradient // 条形的渐变填充（为空时使用条形样式）
 }
 
 // NewBarChart 创建新的条形图
@@ -35,10 +38,10 @@ func NewBarChart() *BarChart {
 		Data:        make(map[string]int),
 		Width:       50,
 		MaxBarWidth: 30,
-		BarStyle:    New().Cyan(),
-		LabelStyle:  New(),
-		ValueStyle:  New().Bold(),
-		TitleStyle:  New().Bold().Underline(),
+		BarStyle:    nil,
+		LabelStyle:  nil,
+		ValueStyle:  nil,
+		TitleStyle:  nil,
 		BarChar:     "█",
 	}
 }
@@ -103,17 +106,40 @@ func (c *BarChart) SetBarChar(char string) *BarChart {
 	return c
 }
 
-// String 返回条形图的字符串表示
+// SetTheme 设置图表使用的主题（为空时使用全局主题）
+func (c *BarChart) SetTheme(theme *Theme) *BarChart {
+	c.Theme = theme
+	return c
+}
+
+// SetGradient 设置条形的渐变填充，渐变按最大条形宽度分布，条形越长末端越接近渐变的终点
+func (c *BarChart) SetGradient(gradient *ColorGradient) *BarChart {
+	c.Gradient = gradient
+	return c
+}
+
+// String 返回条形图的字符串表示（使用默认渲染器的颜色能力）
 func (c *BarChart) String() string {
+	return c.render(DefaultRenderer())
+}
+
+// render 使用指定的渲染器返回条形图的字符串表示
+func (c *BarChart) render(r *Renderer) string {
 	if len(c.Data) == 0 {
 		return "空图表（没有数据）"
 	}
 
 	var result strings.Builder
 
+	// 获取样式（图表自身的样式优先于主题）
+	theme := themeOr(c.Theme)
+	titleStyle := styleOr(c.TitleStyle, theme.Title)
+	barStyle := styleOr(c.BarStyle, theme.Accent)
+	valueStyle := styleOr(c.ValueStyle, theme.Value)
+
 	// 添加标题
 	if c.Title != "" {
-		titleStr := c.TitleStyle.Sprint(c.Title)
+		titleStr := r.Sprint(titleStyle, c.Title)
 		result.WriteString(titleStr + "\n\n")
 	}
 
@@ -121,8 +147,8 @@ func (c *BarChart) String() string {
 	maxLabelLength := 0
 	maxValue := 0
 	for label, value := range c.Data {
-		if len(label) > maxLabelLength {
-			maxLabelLength = len(label)
+		if w := StringWidth(label); w > maxLabelLength {
+			maxLabelLength = w
 		}
 		if value > maxValue {
 			maxValue = value
@@ -134,10 +160,11 @@ func (c *BarChart) String() string {
 		maxLabelLength = 4
 	}
 
-	// 遍历每个数据项
-	for label, value := range c.Data {
+	// 遍历每个数据项（按标签排序，保证输出稳定）
+	for _, label := range sortedLabels(c.Data) {
+		value := c.Data[label]
 		// 绘制标签
-		labelStr := c.LabelStyle.Sprint(fmt.Sprintf("%-*s", maxLabelLength, label))
+		labelStr := r.Sprint(c.LabelStyle, label+strings.Repeat(" ", maxLabelLength-StringWidth(label)))
 		result.WriteString(labelStr)
 		result.WriteString(" │ ")
 
@@ -148,21 +175,40 @@ func (c *BarChart) String() string {
 		}
 
 		// 绘制条形
-		bar := strings.Repeat(c.BarChar, barWidth)
-		barStr := c.BarStyle.Sprint(bar)
-		result.WriteString(barStr)
+		if c.Gradient != nil {
+			result.WriteString(c.Gradient.fill(r, nil, c.BarChar, barWidth, c.MaxBarWidth))
+		} else {
+			bar := strings.Repeat(c.BarChar, barWidth)
+			barStr := r.Sprint(barStyle, bar)
+			result.WriteString(barStr)
+		}
 
 		// 在条形后显示值
-		valueStr := c.ValueStyle.Sprint(fmt.Sprintf(" %d", value))
+		valueStr := r.Sprint(valueStyle, fmt.Sprintf(" %d", value))
 		result.WriteString(valueStr + "\n")
 	}
 
 	return result.String()
 }
 
+// sortedLabels 返回按字母顺序排列的标签，使图表的输出与 map 的遍历顺序无关
+func sortedLabels(data map[string]int) []string {
+	labels := make([]string, 0, len(data))
+	for label := range data {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
 // Print 打印条形图
 func (c *BarChart) Print() {
-	fmt.Print(c.String())
+	c.Fprint(Output)
+}
+
+// Fprint 打印条形图到指定的 writer（根据该 writer 决定颜色能力）
+func (c *BarChart) Fprint(w io.Writer) {
+	fmt.Fprint(w, c.render(rendererFor(w)))
 }
 
 // PieChart 表示饼图
@@ -170,10 +216,11 @@ type PieChart struct {
 	Title       string            // 图表标题
 	Data        map[string]int    // 数据（标签 -> 值）
 	Size        int               // 饼图大小（直径）
-	Styles      map[string]*Style // 每个部分的样式
-	TitleStyle  *Style            // 标题样式
+	Styles      map[string]*Style // 每个部分的样式（未设置的部分使用主题调色板）
+	TitleStyle  *Style            // 标题样式（为空时使用主题中的标题样式）
 	LegendStyle *Style            // 图例样式
-	ValueStyle  *Style            // 值样式
+	ValueStyle  *Style            // 值样式（为空时使用主题中的数值样式）
+	Theme       *Theme            // 主题（为空时使用全局主题）
 }
 
 // NewPieChart 创建新的饼图
@@ -183,9 +230,9 @@ func NewPieChart() *PieChart {
 		Data:        make(map[string]int),
 		Size:        15,
 		Styles:      make(map[string]*Style),
-		TitleStyle:  New().Bold().Underline(),
-		LegendStyle: New(),
-		ValueStyle:  New().Bold(),
+		TitleStyle:  nil,
+		LegendStyle: nil,
+		ValueStyle:  nil,
 	}
 }
 
@@ -243,17 +290,32 @@ func (p *PieChart) SetValueStyle(style *Style) *PieChart {
 	return p
 }
 
-// String 返回饼图的字符串表示
+// SetTheme 设置图表使用的主题（为空时使用全局主题）
+func (p *PieChart) SetTheme(theme *Theme) *PieChart {
+	p.Theme = theme
+	return p
+}
+
+// String 返回饼图的字符串表示（使用默认渲染器的颜色能力）
 func (p *PieChart) String() string {
+	return p.render(DefaultRenderer())
+}
+
+// render 使用指定的渲染器返回饼图的字符串表示
+func (p *PieChart) render(r *Renderer) string {
 	if len(p.Data) == 0 {
 		return "空图表（没有数据）"
 	}
 
 	var result strings.Builder
 
+	// 获取样式（图表自身的样式优先于主题）
+	theme := themeOr(p.Theme)
+	valueStyle := styleOr(p.ValueStyle, theme.Value)
+
 	// 添加标题
 	if p.Title != "" {
-		titleStr := p.TitleStyle.Sprint(p.Title)
+		titleStr := r.Sprint(styleOr(p.TitleStyle, theme.Title), p.Title)
 		result.WriteString(titleStr + "\n\n")
 	}
 
@@ -268,17 +330,6 @@ func (p *PieChart) String() string {
 		return "无法绘制饼图：所有值都为0"
 	}
 
-	// 创建默认样式
-	defaultStyles := []*Style{
-		New().Red(),
-		New().Green(),
-		New().Yellow(),
-		New().Blue(),
-		New().Magenta(),
-		New().Cyan(),
-		New().White(),
-	}
-
 	// 准备数据和角度
 	type pieSection struct {
 		label      string
@@ -293,7 +344,8 @@ func (p *PieChart) String() string {
 	currentAngle := 0.0
 
 	i := 0
-	for label, value := range p.Data {
+	for _, label := range sortedLabels(p.Data) {
+		value := p.Data[label]
 		percentage := float64(value) / float64(total)
 		sectionAngle := percentage * 360.0
 		endAngle := currentAngle + sectionAngle
@@ -303,7 +355,7 @@ func (p *PieChart) String() string {
 		if s, ok := p.Styles[label]; ok {
 			style = s
 		} else {
-			style = defaultStyles[i%len(defaultStyles)]
+			style = theme.PaletteStyle(i)
 		}
 
 		sections = append(sections, pieSection{
@@ -371,7 +423,7 @@ func (p *PieChart) String() string {
 						char = "●" // 中间部分使用实心圆形
 					}
 
-					grid[y][x] = section.style.Sprint(char)
+					grid[y][x] = r.Sprint(section.style, char)
 					break
 				}
 			}
@@ -390,10 +442,10 @@ func (p *PieChart) String() string {
 
 	// 创建图例
 	for _, section := range sections {
-		legendSymbol := section.style.Sprint("●")
-		labelText := p.LegendStyle.Sprint(section.label)
-		percentage := p.ValueStyle.Sprint(fmt.Sprintf("%.1f%%", section.percentage*100))
-		value := p.ValueStyle.Sprint(fmt.Sprintf("(%d)", section.value))
+		legendSymbol := r.Sprint(section.style, "●")
+		labelText := r.Sprint(p.LegendStyle, section.label)
+		percentage := r.Sprint(valueStyle, fmt.Sprintf("%.1f%%", section.percentage*100))
+		value := r.Sprint(valueStyle, fmt.Sprintf("(%d)", section.value))
 
 		result.WriteString(fmt.Sprintf("%s %s: %s %s\n", legendSymbol, labelText, percentage, value))
 	}
@@ -403,26 +455,32 @@ func (p *PieChart) String() string {
 
 // Print 打印饼图
 func (p *PieChart) Print() {
-	fmt.Print(p.String())
+	p.Fprint(Output)
+}
+
+// Fprint 打印饼图到指定的 writer（根据该 writer 决定颜色能力）
+func (p *PieChart) Fprint(w io.Writer) {
+	fmt.Fprint(w, p.render(rendererFor(w)))
 }
 
 // LineChart 表示折线图
 type LineChart struct {
 	Title      string             // 图表标题
 	Data       map[string][]Point // 数据（系列名 -> 点集合）
-	Width      int                // 图表宽度
+	Width      int                // 图表宽度（输出到终端时不超过终端宽度，小于等于 0 时填满终端宽度）
 	Height     int                // 图表高度
 	XAxisTitle string             // X轴标题
 	YAxisTitle string             // Y轴标题
 	ShowGrid   bool               // 是否显示网格
 
 	// 样式设置
-	TitleStyle   *Style            // 标题样式
-	AxisStyle    *Style            // 坐标轴样式
-	GridStyle    *Style            // 网格样式
+	TitleStyle   *Style            // 标题样式（为空时使用主题中的标题样式）
+	AxisStyle    *Style            // 坐标轴样式（为空时使用主题中的边框样式）
+	GridStyle    *Style            // 网格样式（为空时使用主题中的弱化样式）
 	LegendStyle  *Style            // 图例样式
-	LineStyles   map[string]*Style // 每条线的样式
-	MarkerStyles map[string]*Style // 每条线标记点的样式
+	LineStyles   map[string]*Style // 每条线的样式（未设置的系列使用主题调色板）
+	MarkerStyles map[string]*Style // 每条线标记点的样式（未设置的系列使用主题调色板）
+	Theme        *Theme            // 主题（为空时使用全局主题）
 }
 
 // Point 表示折线图中的点
@@ -431,6 +489,9 @@ type Point struct {
 	Y float64
 }
 
+// minLineChartWidth 折线图的最小宽度（Y 轴标签占用 8 列）
+const minLineChartWidth = 20
+
 // NewLineChart 创建新的折线图
 func NewLineChart() *LineChart {
 	return &LineChart{
@@ -441,10 +502,10 @@ func NewLineChart() *LineChart {
 		XAxisTitle:   "",
 		YAxisTitle:   "",
 		ShowGrid:     true,
-		TitleStyle:   New().Bold().Underline(),
-		AxisStyle:    New(),
-		GridStyle:    New().Faint(),
-		LegendStyle:  New(),
+		TitleStyle:   nil,
+		AxisStyle:    nil,
+		GridStyle:    nil,
+		LegendStyle:  nil,
 		LineStyles:   make(map[string]*Style),
 		MarkerStyles: make(map[string]*Style),
 	}
@@ -471,7 +532,7 @@ func (c *LineChart) AddSeries(series string, points []Point) *LineChart {
 	return c
 }
 
-// SetWidth 设置图表宽度
+// SetWidth 设置图表宽度，输出到终端时不超过终端宽度，小于等于 0 时填满终端宽度
 func (c *LineChart) SetWidth(width int) *LineChart {
 	c.Width = width
 	return c
@@ -526,28 +587,55 @@ func (c *LineChart) SetMarkerStyle(series string, style *Style) *LineChart {
 	return c
 }
 
-// String 返回折线图的字符串表示
+// SetTheme 设置图表使用的主题（为空时使用全局主题）
+func (c *LineChart) SetTheme(theme *Theme) *LineChart {
+	c.Theme = theme
+	return c
+}
+
+// String 返回折线图的字符串表示（使用默认渲染器的颜色能力）
 func (c *LineChart) String() string {
+	return c.render(DefaultRenderer())
+}
+
+// render 使用指定的渲染器返回折线图的字符串表示
+func (c *LineChart) render(r *Renderer) string {
 	if len(c.Data) == 0 {
 		return "空图表（没有数据）"
 	}
 
+	// 输出到终端时宽度不超过终端宽度，宽度小于等于 0 时填满终端宽度
+	width := c.Width
+	if width <= 0 {
+		width = NewTerminal(r).Width()
+	} else if max := outputWidth(r); max > 0 && width > max {
+		width = max
+	}
+	if width < minLineChartWidth {
+		width = minLineChartWidth
+	}
+
 	var result strings.Builder
 
+	// 获取样式（图表自身的样式优先于主题）
+	theme := themeOr(c.Theme)
+	axisStyle := styleOr(c.AxisStyle, theme.Border)
+	gridStyle := styleOr(c.GridStyle, theme.Muted)
+
 	// 添加标题
 	if c.Title != "" {
-		titleStr := c.TitleStyle.Sprint(c.Title)
+		titleStr := r.Sprint(styleOr(c.TitleStyle, theme.Title), c.Title)
 		result.WriteString(titleStr + "\n\n")
 	}
 
-	// 设置默认线型和标记
-	defaultLineStyles := []*Style{
-		New().Red(),
-		New().Green(),
-		New().Yellow(),
-		New().Blue(),
-		New().Magenta(),
-		New().Cyan(),
+	// 每个系列的线条和标记样式（未设置的系列使用主题调色板）
+	lineStyles := make(map[string]*Style, len(c.Data))
+	markerStyles := make(map[string]*Style, len(c.Data))
+	for series, style := range c.LineStyles {
+		lineStyles[series] = style
+	}
+	for series, style := range c.MarkerStyles {
+		markerStyles[series] = style
 	}
 
 	defaultMarkers := []string{"●", "■", "▲", "◆", "✦", "✱"}
@@ -556,22 +644,21 @@ func (c *LineChart) String() string {
 	var xMin, xMax, yMin, yMax float64
 	first := true
 
-	for series, points := range c.Data {
+	seriesNames := c.seriesNames()
+	for _, series := range seriesNames {
+		points := c.Data[series]
 		if len(points) == 0 {
 			continue
 		}
 
 		// 为每个系列设置默认样式
-		if _, ok := c.LineStyles[series]; !ok {
-			seriesIndex := len(c.LineStyles) % len(defaultLineStyles)
-			c.LineStyles[series] = defaultLineStyles[seriesIndex]
+		if _, ok := lineStyles[series]; !ok {
+			lineStyles[series] = theme.PaletteStyle(len(lineStyles))
 		}
 
 		// 为每个系列设置默认标记
-		if _, ok := c.MarkerStyles[series]; !ok {
-			seriesIndex := len(c.MarkerStyles) % len(defaultLineStyles)
-			markerStyle := defaultLineStyles[seriesIndex]
-			c.MarkerStyles[series] = markerStyle
+		if _, ok := markerStyles[series]; !ok {
+			markerStyles[series] = theme.PaletteStyle(len(markerStyles))
 		}
 
 		for _, p := range points {
@@ -606,13 +693,13 @@ func (c *LineChart) String() string {
 	bottomMargin := 2 // 底部边距，用于X轴标签
 
 	// 图表实际绘制区域
-	plotWidth := c.Width - leftMargin
+	plotWidth := width - leftMargin
 	plotHeight := c.Height - bottomMargin
 
 	// 创建绘图区域（包括坐标轴）
 	gridChars := make([][]string, c.Height)
 	for i := range gridChars {
-		gridChars[i] = make([]string, c.Width)
+		gridChars[i] = make([]string, width)
 		for j := range gridChars[i] {
 			gridChars[i][j] = " "
 		}
@@ -621,11 +708,11 @@ func (c *LineChart) String() string {
 	// 绘制Y轴
 	for i := 0; i < c.Height-bottomMargin; i++ {
 		yPos := c.Height - bottomMargin - 1 - i
-		gridChars[yPos][leftMargin-1] = c.AxisStyle.Sprint("│")
+		gridChars[yPos][leftMargin-1] = r.Sprint(axisStyle, "│")
 
 		// 绘制Y轴刻度和标签
 		if i%(plotHeight/4) == 0 || i == plotHeight-1 {
-			gridChars[yPos][leftMargin-1] = c.AxisStyle.Sprint("┤")
+			gridChars[yPos][leftMargin-1] = r.Sprint(axisStyle, "┤")
 
 			// 计算当前Y值
 			y := yMin + (float64(i)/float64(plotHeight-1))*(yMax-yMin)
@@ -633,7 +720,7 @@ func (c *LineChart) String() string {
 
 			// 添加Y轴标签
 			for j := 0; j < len(yLabel) && j < leftMargin-1; j++ {
-				gridChars[yPos][j] = c.AxisStyle.Sprint(string(yLabel[j]))
+				gridChars[yPos][j] = r.Sprint(axisStyle, string(yLabel[j]))
 			}
 		}
 
@@ -642,9 +729,9 @@ func (c *LineChart) String() string {
 			for j := 0; j < plotWidth; j++ {
 				if gridChars[yPos][leftMargin+j] == " " {
 					if i%(plotHeight/4) == 0 {
-						gridChars[yPos][leftMargin+j] = c.GridStyle.Sprint("─")
+						gridChars[yPos][leftMargin+j] = r.Sprint(gridStyle, "─")
 					} else {
-						gridChars[yPos][leftMargin+j] = c.GridStyle.Sprint("·")
+						gridChars[yPos][leftMargin+j] = r.Sprint(gridStyle, "·")
 					}
 				}
 			}
@@ -654,21 +741,21 @@ func (c *LineChart) String() string {
 	// 绘制X轴
 	for j := 0; j < plotWidth; j++ {
 		xPos := leftMargin + j
-		gridChars[c.Height-bottomMargin][xPos] = c.AxisStyle.Sprint("─")
+		gridChars[c.Height-bottomMargin][xPos] = r.Sprint(axisStyle, "─")
 
 		// 绘制X轴刻度和标签
 		if j%(plotWidth/5) == 0 || j == plotWidth-1 {
-			gridChars[c.Height-bottomMargin][xPos] = c.AxisStyle.Sprint("┬")
+			gridChars[c.Height-bottomMargin][xPos] = r.Sprint(axisStyle, "┬")
 
 			// 计算当前X值
 			x := xMin + (float64(j)/float64(plotWidth-1))*(xMax-xMin)
 			xLabel := fmt.Sprintf("%.1f", x)
 
 			// 添加X轴标签（居中对齐）
-			for k := 0; k < len(xLabel) && xPos+k-len(xLabel)/2 < c.Width && xPos+k-len(xLabel)/2 >= 0; k++ {
+			for k := 0; k < len(xLabel) && xPos+k-len(xLabel)/2 < width && xPos+k-len(xLabel)/2 >= 0; k++ {
 				charPos := xPos + k - len(xLabel)/2
-				if charPos < c.Width {
-					gridChars[c.Height-bottomMargin+1][charPos] = c.AxisStyle.Sprint(string(xLabel[k]))
+				if charPos < width {
+					gridChars[c.Height-bottomMargin+1][charPos] = r.Sprint(axisStyle, string(xLabel[k]))
 				}
 			}
 		}
@@ -679,9 +766,9 @@ func (c *LineChart) String() string {
 				yPos := c.Height - bottomMargin - 1 - i
 				if gridChars[yPos][xPos] == " " || gridChars[yPos][xPos] == "·" {
 					if j%(plotWidth/5) == 0 {
-						gridChars[yPos][xPos] = c.GridStyle.Sprint("│")
+						gridChars[yPos][xPos] = r.Sprint(gridStyle, "│")
 					} else if gridChars[yPos][xPos] != "─" {
-						gridChars[yPos][xPos] = c.GridStyle.Sprint("·")
+						gridChars[yPos][xPos] = r.Sprint(gridStyle, "·")
 					}
 				}
 			}
@@ -689,18 +776,19 @@ func (c *LineChart) String() string {
 	}
 
 	// 标记轴的交点
-	gridChars[c.Height-bottomMargin][leftMargin-1] = c.AxisStyle.Sprint("┼")
+	gridChars[c.Height-bottomMargin][leftMargin-1] = r.Sprint(axisStyle, "┼")
 
 	// 绘制每个数据系列的线条
 	seriesIndex := 0
-	for series, points := range c.Data {
+	for _, series := range seriesNames {
+		points := c.Data[series]
 		if len(points) < 2 {
 			continue
 		}
 
 		// 获取线条和标记样式
-		lineStyle := c.LineStyles[series]
-		markerStyle := c.MarkerStyles[series]
+		lineStyle := lineStyles[series]
+		markerStyle := markerStyles[series]
 		marker := defaultMarkers[seriesIndex%len(defaultMarkers)]
 
 		// 按X值排序点
@@ -734,7 +822,7 @@ func (c *LineChart) String() string {
 			y = c.Height - bottomMargin - 1 - y
 
 			// 绘制标记点
-			gridChars[y][x] = markerStyle.Sprint(marker)
+			gridChars[y][x] = r.Sprint(markerStyle, marker)
 
 			// 绘制线段连接当前点和上一个点
 			if !first {
@@ -747,7 +835,7 @@ func (c *LineChart) String() string {
 					tx := lastX + i*dx/steps
 					ty := lastY + i*dy/steps
 
-					if tx >= 0 && tx < c.Width && ty >= 0 && ty < c.Height {
+					if tx >= 0 && tx < width && ty >= 0 && ty < c.Height {
 						// 使用适当的字符来表示线条方向
 						lineChar := "─"
 						if dx == 0 {
@@ -765,7 +853,7 @@ func (c *LineChart) String() string {
 							gridChars[ty][tx] == "·" ||
 							gridChars[ty][tx] == "─" ||
 							gridChars[ty][tx] == "│" {
-							gridChars[ty][tx] = lineStyle.Sprint(lineChar)
+							gridChars[ty][tx] = r.Sprint(lineStyle, lineChar)
 						}
 					}
 				}
@@ -780,7 +868,7 @@ func (c *LineChart) String() string {
 
 	// 渲染图表
 	for i := 0; i < c.Height; i++ {
-		for j := 0; j < c.Width; j++ {
+		for j := 0; j < width; j++ {
 			result.WriteString(gridChars[i][j])
 		}
 		result.WriteString("\n")
@@ -789,10 +877,10 @@ func (c *LineChart) String() string {
 	// 添加图例
 	result.WriteString("\n图例: ")
 	seriesIndex = 0
-	for series := range c.Data {
-		lineStyle := c.LineStyles[series]
+	for _, series := range seriesNames {
+		lineStyle := lineStyles[series]
 		marker := defaultMarkers[seriesIndex%len(defaultMarkers)]
-		result.WriteString(lineStyle.Sprint(marker + " " + series + " "))
+		result.WriteString(r.Sprint(lineStyle, marker+" "+series+" "))
 		seriesIndex++
 	}
 	result.WriteString("\n")
@@ -800,7 +888,22 @@ func (c *LineChart) String() string {
 	return result.String()
 }
 
+// seriesNames 返回按字母顺序排列的系列名称，使图表的输出与 map 的遍历顺序无关
+func (c *LineChart) seriesNames() []string {
+	names := make([]string, 0, len(c.Data))
+	for series := range c.Data {
+		names = append(names, series)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Print 打印折线图
 func (c *LineChart) Print() {
-	fmt.Print(c.String())
+	c.Fprint(Output)
+}
+
+// Fprint 打印折线图到指定的 writer（根据该 writer 决定颜色能力）
+func (c *LineChart) Fprint(w io.Writer) {
+	fmt.Fprint(w, c.render(rendererFor(w)))
 }
@@ -0,0 +1,481 @@
+package goterm
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Color 表示一个颜色，可以是 RGB 颜色或 256 色调色板中的颜色
+type Color struct {
+	R, G, B uint8 // 颜色的 RGB 分量
+	index   int   // 调色板索引加 1，0 表示不是调色板颜色
+}
+
+// NewColor 使用 RGB 分量创建颜色，分量会被限制在 0-255 范围内
+func NewColor(r, g, b int) Color {
+	return Color{R: uint8(clampColor(r)), G: uint8(clampColor(g)), B: uint8(clampColor(b))}
+}
+
+// ANSIColor 使用 256 色调色板索引（0-255）创建颜色，
+// 0-15 为终端主题定义的基本颜色，渲染时会使用对应的调色板代码
+func ANSIColor(index int) Color {
+	if index < 0 {
+		index = 0
+	}
+	if index > 255 {
+		index = 255
+	}
+	r, g, b := ansi256ToRGB(index)
+	return Color{R: uint8(r), G: uint8(g), B: uint8(b), index: index + 1}
+}
+
+// Hex 解析十六进制颜色，支持 #rrggbb 和 #rgb 格式（# 可以省略）
+func Hex(s string) (Color, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return Color{}, fmt.Errorf("goterm: invalid hex color %q", s)
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("goterm: invalid hex color %q", s)
+	}
+	return Color{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n)}, nil
+}
+
+// MustHex 解析十六进制颜色，格式错误时 panic，适用于定义常量颜色
+func MustHex(s string) Color {
+	c, err := Hex(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// HSL 使用色相（0-360）、饱和度（0-1）和亮度（0-1）创建颜色
+func HSL(h, s, l float64) Color {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s = clampUnit(s)
+	l = clampUnit(l)
+
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return NewColor(unitToByte(r+m), unitToByte(g+m), unitToByte(b+m))
+}
+
+// CSSColor 返回 CSS 颜色名称对应的颜色
+func CSSColor(name string) (Color, bool) {
+	rgb, ok := cssColors[strings.ToLower(name)]
+	if !ok {
+		return Color{}, false
+	}
+	return Color{R: rgb[0], G: rgb[1], B: rgb[2]}, true
+}
+
+// ParseColor 解析颜色描述，支持以下格式：
+//   - 基本颜色名称：red、bright-red 等（使用终端主题的调色板颜色）
+//   - CSS 颜色名称：orange、rebeccapurple 等
+//   - 十六进制：#ff8800、#f80
+//   - RGB：rgb(255,136,0)
+//   - HSL：hsl(32,1,0.5)
+//   - 256 色索引：color(208)
+func ParseColor(s string) (Color, error) {
+	spec := strings.ToLower(strings.TrimSpace(s))
+
+	if index, ok := namedColors[spec]; ok {
+		return ANSIColor(index), nil
+	}
+	if c, ok := CSSColor(spec); ok {
+		return c, nil
+	}
+
+	switch {
+	case strings.HasPrefix(spec, "#"):
+		return Hex(spec)
+
+	case strings.HasPrefix(spec, "rgb(") && strings.HasSuffix(spec, ")"):
+		values, err := parseColorArgs(spec[4:len(spec)-1], 3)
+		if err != nil {
+			return Color{}, fmt.Errorf("goterm: invalid rgb color %q", s)
+		}
+		return NewColor(int(values[0]), int(values[1]), int(values[2])), nil
+
+	case strings.HasPrefix(spec, "hsl(") && strings.HasSuffix(spec, ")"):
+		values, err := parseColorArgs(spec[4:len(spec)-1], 3)
+		if err != nil {
+			return Color{}, fmt.Errorf("goterm: invalid hsl color %q", s)
+		}
+		return HSL(values[0], values[1], values[2]), nil
+
+	case strings.HasPrefix(spec, "color(") && strings.HasSuffix(spec, ")"):
+		values, err := parseColorArgs(spec[6:len(spec)-1], 1)
+		if err != nil || values[0] < 0 || values[0] > 255 {
+			return Color{}, fmt.Errorf("goterm: invalid color index %q", s)
+		}
+		return ANSIColor(int(values[0])), nil
+	}
+
+	return Color{}, fmt.Errorf("goterm: unknown color %q", s)
+}
+
+// parseColorArgs 解析逗号分隔的颜色参数
+func parseColorArgs(s string, n int) ([]float64, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != n {
+		return nil, fmt.Errorf("goterm: expected %d color arguments, got %d", n, len(parts))
+	}
+	values := make([]float64, n)
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// Hex 返回颜色的十六进制表示，例如 #ff8800
+func (c Color) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// String 返回颜色的字符串表示
+func (c Color) String() string {
+	if c.index > 0 {
+		return "color(" + strconv.Itoa(c.index-1) + ")"
+	}
+	return c.Hex()
+}
+
+// Index 返回颜色在 256 色调色板中的索引，不是调色板颜色时返回 false
+func (c Color) Index() (int, bool) {
+	return c.index - 1, c.index > 0
+}
+
+// HSL 返回颜色的色相（0-360）、饱和度（0-1）和亮度（0-1）
+func (c Color) HSL() (h, s, l float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	l = (max + min) / 2
+
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+
+	switch max {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h * 60, s, l
+}
+
+// Lighten 返回提高亮度后的颜色，amount 为亮度增加量（0-1）
+func (c Color) Lighten(amount float64) Color {
+	h, s, l := c.HSL()
+	return HSL(h, s, l+amount)
+}
+
+// Darken 返回降低亮度后的颜色，amount 为亮度减少量（0-1）
+func (c Color) Darken(amount float64) Color {
+	return c.Lighten(-amount)
+}
+
+// Blend 返回与另一个颜色按比例混合后的颜色，t 为 0 时返回当前颜色，为 1 时返回 other
+func (c Color) Blend(other Color, t float64) Color {
+	t = clampUnit(t)
+	mix := func(a, b uint8) int {
+		return int(math.Round(float64(a) + (float64(b)-float64(a))*t))
+	}
+	return NewColor(mix(c.R, other.R), mix(c.G, other.G), mix(c.B, other.B))
+}
+
+// Luminance 返回颜色的相对亮度（WCAG 定义，0-1）
+func (c Color) Luminance() float64 {
+	linear := func(v uint8) float64 {
+		f := float64(v) / 255
+		if f <= 0.03928 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(c.R) + 0.7152*linear(c.G) + 0.0722*linear(c.B)
+}
+
+// ContrastRatio 返回两个颜色之间的对比度（WCAG 定义，1-21）
+func (c Color) ContrastRatio(other Color) float64 {
+	l1, l2 := c.Luminance(), other.Luminance()
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// IsDark 返回颜色是否为深色
+func (c Color) IsDark() bool {
+	return c.ContrastRatio(Color{R: 255, G: 255, B: 255}) > c.ContrastRatio(Color{})
+}
+
+// ReadableForeground 返回以当前颜色为背景时可读性最好的前景色（黑色或白色）
+func (c Color) ReadableForeground() Color {
+	return BestContrast(c, Color{}, Color{R: 255, G: 255, B: 255})
+}
+
+// BestContrast 从候选颜色中返回与背景色对比度最高的颜色
+func BestContrast(background Color, candidates ...Color) Color {
+	var best Color
+	bestRatio := -1.0
+	for _, candidate := range candidates {
+		if ratio := background.ContrastRatio(candidate); ratio > bestRatio {
+			best, bestRatio = candidate, ratio
+		}
+	}
+	return best
+}
+
+// code 返回颜色的 SGR 代码，kind 为 38（前景色）、48（背景色）或 58（下划线颜色）
+func (c Color) code(kind string) string {
+	if index, ok := c.Index(); ok {
+		if index < 16 && kind != "58" {
+			return "\033[" + basicParams(kind, index) + "m"
+		}
+		return "\033[" + indexedParams(kind, index) + "m"
+	}
+	return rgbCode(kind, int(c.R), int(c.G), int(c.B))
+}
+
+// rgbCode 返回 RGB 颜色的 SGR 代码
+func rgbCode(kind string, r, g, b int) string {
+	return "\033[" + kind + ";2;" + strconv.Itoa(clampColor(r)) + ";" +
+		strconv.Itoa(clampColor(g)) + ";" + strconv.Itoa(clampColor(b)) + "m"
+}
+
+// clampUnit 将数值限制在 0-1 范围内
+func clampUnit(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+// unitToByte 将 0-1 范围内的数值转换为 0-255 的颜色分量
+func unitToByte(v float64) int {
+	return int(math.Round(clampUnit(v) * 255))
+}
+
+// namedColors 基本颜色名称及其 16 色索引
+var namedColors = map[string]int{
+	"black":          0,
+	"red":            1,
+	"green":          2,
+	"yellow":         3,
+	"blue":           4,
+	"magenta":        5,
+	"cyan":           6,
+	"white":          7,
+	"bright-black":   8,
+	"gray":           8,
+	"grey":           8,
+	"bright-red":     9,
+	"bright-green":   10,
+	"bright-yellow":  11,
+	"bright-blue":    12,
+	"bright-magenta": 13,
+	"bright-cyan":    14,
+	"bright-white":   15,
+}
+
+// cssColors CSS 颜色名称及其 RGB 值
+var cssColors = map[string][3]uint8{
+	"aliceblue":            {0xf0, 0xf8, 0xff},
+	"antiquewhite":         {0xfa, 0xeb, 0xd7},
+	"aqua":                 {0x00, 0xff, 0xff},
+	"aquamarine":           {0x7f, 0xff, 0xd4},
+	"azure":                {0xf0, 0xff, 0xff},
+	"beige":                {0xf5, 0xf5, 0xdc},
+	"bisque":               {0xff, 0xe4, 0xc4},
+	"black":                {0x00, 0x00, 0x00},
+	"blanchedalmond":       {0xff, 0xeb, 0xcd},
+	"blue":                 {0x00, 0x00, 0xff},
+	"blueviolet":           {0x8a, 0x2b, 0xe2},
+	"brown":                {0xa5, 0x2a, 0x2a},
+	"burlywood":            {0xde, 0xb8, 0x87},
+	"cadetblue":            {0x5f, 0x9e, 0xa0},
+	"chartreuse":           {0x7f, 0xff, 0x00},
+	"chocolate":            {0xd2, 0x69, 0x1e},
+	"coral":                {0xff, 0x7f, 0x50},
+	"cornflowerblue":       {0x64, 0x95, 0xed},
+	"cornsilk":             {0xff, 0xf8, 0xdc},
+	"crimson":              {0xdc, 0x14, 0x3c},
+	"cyan":                 {0x00, 0xff, 0xff},
+	"darkblue":             {0x00, 0x00, 0x8b},
+	"darkcyan":             {0x00, 0x8b, 0x8b},
+	"darkgoldenrod":        {0xb8, 0x86, 0x0b},
+	"darkgray":             {0xa9, 0xa9, 0xa9},
+	"darkgreen":            {0x00, 0x64, 0x00},
+	"darkgrey":             {0xa9, 0xa9, 0xa9},
+	"darkkhaki":            {0xbd, 0xb7, 0x6b},
+	"darkmagenta":          {0x8b, 0x00, 0x8b},
+	"darkolivegreen":       {0x55, 0x6b, 0x2f},
+	"darkorange":           {0xff, 0x8c, 0x00},
+	"darkorchid":           {0x99, 0x32, 0xcc},
+	"darkred":              {0x8b, 0x00, 0x00},
+	"darksalmon":           {0xe9, 0x96, 0x7a},
+	"darkseagreen":         {0x8f, 0xbc, 0x8f},
+	"darkslateblue":        {0x48, 0x3d, 0x8b},
+	"darkslategray":        {0x2f, 0x4f, 0x4f},
+	"darkslategrey":        {0x2f, 0x4f, 0x4f},
+	"darkturquoise":        {0x00, 0xce, 0xd1},
+	"darkviolet":           {0x94, 0x00, 0xd3},
+	"deeppink":             {0xff, 0x14, 0x93},
+	"deepskyblue":          {0x00, 0xbf, 0xff},
+	"dimgray":              {0x69, 0x69, 0x69},
+	"dimgrey":              {0x69, 0x69, 0x69},
+	"dodgerblue":           {0x1e, 0x90, 0xff},
+	"firebrick":            {0xb2, 0x22, 0x22},
+	"floralwhite":          {0xff, 0xfa, 0xf0},
+	"forestgreen":          {0x22, 0x8b, 0x22},
+	"fuchsia":              {0xff, 0x00, 0xff},
+	"gainsboro":            {0xdc, 0xdc, 0xdc},
+	"ghostwhite":           {0xf8, 0xf8, 0xff},
+	"gold":                 {0xff, 0xd7, 0x00},
+	"goldenrod":            {0xda, 0xa5, 0x20},
+	"gray":                 {0x80, 0x80, 0x80},
+	"green":                {0x00, 0x80, 0x00},
+	"greenyellow":          {0xad, 0xff, 0x2f},
+	"grey":                 {0x80, 0x80, 0x80},
+	"honeydew":             {0xf0, 0xff, 0xf0},
+	"hotpink":              {0xff, 0x69, 0xb4},
+	"indianred":            {0xcd, 0x5c, 0x5c},
+	"indigo":               {0x4b, 0x00, 0x82},
+	"ivory":                {0xff, 0xff, 0xf0},
+	"khaki":                {0xf0, 0xe6, 0x8c},
+	"lavender":             {0xe6, 0xe6, 0xfa},
+	"lavenderblush":        {0xff, 0xf0, 0xf5},
+	"lawngreen":            {0x7c, 0xfc, 0x00},
+	"lemonchiffon":         {0xff, 0xfa, 0xcd},
+	"lightblue":            {0xad, 0xd8, 0xe6},
+	"lightcoral":           {0xf0, 0x80, 0x80},
+	"lightcyan":            {0xe0, 0xff, 0xff},
+	"lightgoldenrodyellow": {0xfa, 0xfa, 0xd2},
+	"lightgray":            {0xd3, 0xd3, 0xd3},
+	"lightgreen":           {0x90, 0xee, 0x90},
+	"lightgrey":            {0xd3, 0xd3, 0xd3},
+	"lightpink":            {0xff, 0xb6, 0xc1},
+	"lightsalmon":          {0xff, 0xa0, 0x7a},
+	"lightseagreen":        {0x20, 0xb2, 0xaa},
+	"lightskyblue":         {0x87, 0xce, 0xfa},
+	"lightslategray":       {0x77, 0x88, 0x99},
+	"lightslategrey":       {0x77, 0x88, 0x99},
+	"lightsteelblue":       {0xb0, 0xc4, 0xde},
+	"lightyellow":          {0xff, 0xff, 0xe0},
+	"lime":                 {0x00, 0xff, 0x00},
+	"limegreen":            {0x32, 0xcd, 0x32},
+	"linen":                {0xfa, 0xf0, 0xe6},
+	"magenta":              {0xff, 0x00, 0xff},
+	"maroon":               {0x80, 0x00, 0x00},
+	"mediumaquamarine":     {0x66, 0xcd, 0xaa},
+	"mediumblue":           {0x00, 0x00, 0xcd},
+	"mediumorchid":         {0xba, 0x55, 0xd3},
+	"mediumpurple":         {0x93, 0x70, 0xdb},
+	"mediumseagreen":       {0x3c, 0xb3, 0x71},
+	"mediumslateblue":      {0x7b, 0x68, 0xee},
+	"mediumspringgreen":    {0x00, 0xfa, 0x9a},
+	"mediumturquoise":      {0x48, 0xd1, 0xcc},
+	"mediumvioletred":      {0xc7, 0x15, 0x85},
+	"midnightblue":         {0x19, 0x19, 0x70},
+	"mintcream":            {0xf5, 0xff, 0xfa},
+	"mistyrose":            {0xff, 0xe4, 0xe1},
+	"moccasin":             {0xff, 0xe4, 0xb5},
+	"navajowhite":          {0xff, 0xde, 0xad},
+	"navy":                 {0x00, 0x00, 0x80},
+	"oldlace":              {0xfd, 0xf5, 0xe6},
+	"olive":                {0x80, 0x80, 0x00},
+	"olivedrab":            {0x6b, 0x8e, 0x23},
+	"orange":               {0xff, 0xa5, 0x00},
+	"orangered":            {0xff, 0x45, 0x00},
+	"orchid":               {0xda, 0x70, 0xd6},
+	"palegoldenrod":        {0xee, 0xe8, 0xaa},
+	"palegreen":            {0x98, 0xfb, 0x98},
+	"paleturquoise":        {0xaf, 0xee, 0xee},
+	"palevioletred":        {0xdb, 0x70, 0x93},
+	"papayawhip":           {0xff, 0xef, 0xd5},
+	"peachpuff":            {0xff, 0xda, 0xb9},
+	"peru":                 {0xcd, 0x85, 0x3f},
+	"pink":                 {0xff, 0xc0, 0xcb},
+	"plum":                 {0xdd, 0xa0, 0xdd},
+	"powderblue":           {0xb0, 0xe0, 0xe6},
+	"purple":               {0x80, 0x00, 0x80},
+	"rebeccapurple":        {0x66, 0x33, 0x99},
+	"red":                  {0xff, 0x00, 0x00},
+	"rosybrown":            {0xbc, 0x8f, 0x8f},
+	"royalblue":            {0x41, 0x69, 0xe1},
+	"saddlebrown":          {0x8b, 0x45, 0x13},
+	"salmon":               {0xfa, 0x80, 0x72},
+	"sandybrown":           {0xf4, 0xa4, 0x60},
+	"seagreen":             {0x2e, 0x8b, 0x57},
+	"seashell":             {0xff, 0xf5, 0xee},
+	"sienna":               {0xa0, 0x52, 0x2d},
+	"silver":               {0xc0, 0xc0, 0xc0},
+	"skyblue":              {0x87, 0xce, 0xeb},
+	"slateblue":            {0x6a, 0x5a, 0xcd},
+	"slategray":            {0x70, 0x80, 0x90},
+	"slategrey":            {0x70, 0x80, 0x90},
+	"snow":                 {0xff, 0xfa, 0xfa},
+	"springgreen":          {0x00, 0xff, 0x7f},
+	"steelblue":            {0x46, 0x82, 0xb4},
+	"tan":                  {0xd2, 0xb4, 0x8c},
+	"teal":                 {0x00, 0x80, 0x80},
+	"thistle":              {0xd8, 0xbf, 0xd8},
+	"tomato":               {0xff, 0x63, 0x47},
+	"turquoise":            {0x40, 0xe0, 0xd0},
+	"violet":               {0xee, 0x82, 0xee},
+	"wheat":                {0xf5, 0xde, 0xb3},
+	"white":                {0xff, 0xff, 0xff},
+	"whitesmoke":           {0xf5, 0xf5, 0xf5},
+	"yellow":               {0xff, 0xff, 0x00},
+	"yellowgreen":          {0x9a, 0xcd, 0x32},
+}
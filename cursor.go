@@ -1,68 +1,126 @@
 package goterm
 
-import (
-	"fmt"
-)
-
 // Cursor 提供终端光标控制功能
-type Cursor struct{}
+type Cursor struct {
+	term *Terminal // 光标所在的终端，为空时使用默认终端
+}
 
-// NewCursor 创建一个新的Cursor实例
+// NewCursor 创建一个新的Cursor实例（控制默认终端的光标）
 func NewCursor() *Cursor {
 	return &Cursor{}
 }
 
+// terminal 返回光标所在的终端
+func (c *Cursor) terminal() *Terminal {
+	return terminalOr(c.term)
+}
+
 // MoveUp 将光标向上移动n行
 func (c *Cursor) MoveUp(n int) {
-	fmt.Printf("\033[%dA", n)
+	c.terminal().MoveUp(n)
 }
 
 // MoveDown 将光标向下移动n行
 func (c *Cursor) MoveDown(n int) {
-	fmt.Printf("\033[%dB", n)
+	c.terminal().MoveDown(n)
 }
 
 // MoveRight 将光标向右移动n列
 func (c *Cursor) MoveRight(n int) {
-	fmt.Printf("\033[%dC", n)
+	c.terminal().MoveRight(n)
 }
 
 // MoveLeft 将光标向左移动n列
 func (c *Cursor) MoveLeft(n int) {
-	fmt.Printf("\033[%dD", n)
+	c.terminal().MoveLeft(n)
 }
 
 // MoveTo 将光标移动到指定位置
 func (c *Cursor) MoveTo(row, col int) {
-	fmt.Printf("\033[%d;%dH", row, col)
+	c.terminal().MoveTo(row, col)
+}
+
+// MoveToColumn 将光标移动到当前行的指定列（从 1 开始）
+func (c *Cursor) MoveToColumn(col int) {
+	c.terminal().MoveToColumn(col)
+}
+
+// MoveToNextLine 将光标向下移动n行并移动到行首
+func (c *Cursor) MoveToNextLine(n int) {
+	c.terminal().MoveToNextLine(n)
+}
+
+// MoveToPrevLine 将光标向上移动n行并移动到行首
+func (c *Cursor) MoveToPrevLine(n int) {
+	c.terminal().MoveToPrevLine(n)
+}
+
+// Position 查询光标的位置（行和列均从 1 开始）
+func (c *Cursor) Position() (row, col int, err error) {
+	return c.terminal().CursorPosition()
 }
 
 // SavePosition 保存当前光标位置
 func (c *Cursor) SavePosition() {
-	fmt.Print("\033[s")
+	c.terminal().SavePosition()
 }
 
 // RestorePosition 恢复之前保存的光标位置
 func (c *Cursor) RestorePosition() {
-	fmt.Print("\033[u")
+	c.terminal().RestorePosition()
 }
 
 // ClearScreen 清除整个屏幕
 func (c *Cursor) ClearScreen() {
-	fmt.Print("\033[2J")
+	c.terminal().ClearScreen()
 }
 
 // ClearLine 清除从光标位置到行尾的内容
 func (c *Cursor) ClearLine() {
-	fmt.Print("\033[K")
+	c.terminal().ClearLine()
+}
+
+// ClearLineStart 清除从行首到光标位置的内容
+func (c *Cursor) ClearLineStart() {
+	c.terminal().ClearLineStart()
+}
+
+// ClearEntireLine 清除光标所在的整行
+func (c *Cursor) ClearEntireLine() {
+	c.terminal().ClearEntireLine()
+}
+
+// ClearScreenDown 清除从光标位置到屏幕底部的内容
+func (c *Cursor) ClearScreenDown() {
+	c.terminal().ClearScreenDown()
+}
+
+// ClearScreenUp 清除从屏幕顶部到光标位置的内容
+func (c *Cursor) ClearScreenUp() {
+	c.terminal().ClearScreenUp()
 }
 
 // HideCursor 隐藏光标
 func (c *Cursor) HideCursor() {
-	fmt.Print("\033[?25l")
+	c.terminal().HideCursor()
 }
 
 // ShowCursor 显示光标
 func (c *Cursor) ShowCursor() {
-	fmt.Print("\033[?25h")
+	c.terminal().ShowCursor()
+}
+
+// SetShape 设置光标的形状
+func (c *Cursor) SetShape(shape CursorShape) {
+	c.terminal().SetCursorShape(shape)
+}
+
+// EnterAltScreen 切换到备用屏幕
+func (c *Cursor) EnterAltScreen() {
+	c.terminal().EnterAltScreen()
+}
+
+// ExitAltScreen 离开备用屏幕，恢复原来的屏幕内容
+func (c *Cursor) ExitAltScreen() {
+	c.terminal().ExitAltScreen()
 }
@@ -0,0 +1,98 @@
+package goterm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+// CursorShape 表示光标的形状
+type CursorShape int
+
+// 光标形状（DECSCUSR）
+const (
+	CursorShapeDefault           CursorShape = iota // 终端默认形状
+	CursorShapeBlinkingBlock                        // 闪烁的方块
+	CursorShapeBlock                                // 方块
+	CursorShapeBlinkingUnderline                    // 闪烁的下划线
+	CursorShapeUnderline                            // 下划线
+	CursorShapeBlinkingBar                          // 闪烁的竖线
+	CursorShapeBar                                  // 竖线
+)
+
+// CursorQueryTimeout 查询光标位置时等待终端响应的最长时间
+var CursorQueryTimeout = 200 * time.Millisecond
+
+// ErrQueryTimeout 终端没有在规定时间内响应查询
+var ErrQueryTimeout = errors.New("goterm: terminal did not respond in time")
+
+// SetCursorShape 设置光标的形状
+func (t *Terminal) SetCursorShape(shape CursorShape) {
+	t.Printf("\033[%d q", shape)
+}
+
+// CursorPosition 查询光标的位置（行和列均从 1 开始），终端没有在 CursorQueryTimeout 内响应时返回错误
+func (t *Terminal) CursorPosition() (row, col int, err error) {
+	response, err := t.query("\033[6n", CursorQueryTimeout, func(s string) bool {
+		i := strings.LastIndex(s, "\033[")
+		return i >= 0 && strings.IndexByte(s[i:], 'R') >= 0
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// 响应格式为 ESC [ 行 ; 列 R
+	i := strings.LastIndex(response, "\033[")
+	if _, err := fmt.Sscanf(response[i:], "\033[%d;%dR", &row, &col); err != nil {
+		return 0, 0, fmt.Errorf("goterm: invalid cursor position response %q", response[i:])
+	}
+	return row, col, nil
+}
+
+// query 向终端发送查询序列并读取响应，直到 done 返回 true 或超时。
+// 查询期间终端处于原始模式，避免响应被回显或等待回车
+func (t *Terminal) query(request string, timeout time.Duration, done func(string) bool) (string, error) {
+	in, ok := t.in.(*os.File)
+	if !ok || !isatty.IsTerminal(in.Fd()) {
+		return "", errors.New("goterm: terminal input is not a terminal device")
+	}
+
+	restore, err := t.MakeRawMode(RawModeFull)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	// 标准输入通常不支持读取超时，此时从控制终端读取响应
+	reader := in
+	if err := in.SetReadDeadline(time.Time{}); err != nil {
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			return "", err
+		}
+		defer tty.Close()
+		reader = tty
+	}
+
+	if _, err := t.Print(request); err != nil {
+		return "", err
+	}
+
+	response, ok := readTerminalResponse(reader, timeout, done)
+	if !ok {
+		return "", ErrQueryTimeout
+	}
+	return response, nil
+}
+
+// deviceAttributesReceived 返回响应中是否已经包含 DA1 响应（ESC [ ? ... c）。
+// 几乎所有终端都会响应 DA1，并且响应总是在前面的查询响应之后到达，
+// 因此在查询之后紧跟一个 DA1 查询，终端不支持该查询时不需要等待超时
+func deviceAttributesReceived(s string) bool {
+	i := strings.Index(s, "\033[?")
+	return i >= 0 && strings.IndexByte(s[i:], 'c') >= 0
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lllllan02/goterm"
+)
+
+func main() {
+	// 示例1：自动宽度的方框
+	fmt.Println("示例1：自动宽度的方框")
+	goterm.NewBox("构建完成\n用时 3.2s").Print()
+	fmt.Println()
+
+	// 示例2：各种边框
+	fmt.Println("示例2：各种边框")
+	borders := []struct {
+		name   string
+		border goterm.BorderSet
+	}{
+		{"单线", goterm.BorderSingle},
+		{"双线", goterm.BorderDouble},
+		{"圆角", goterm.BorderRounded},
+		{"粗线", goterm.BorderThick},
+		{"ASCII", goterm.BorderASCII},
+		{"无边框", goterm.BorderNone},
+	}
+	for _, b := range borders {
+		goterm.NewBox(b.name).SetBorder(b.border).SetWidth(20).SetAlign(goterm.AlignCenter).Print()
+	}
+	fmt.Println()
+
+	// 示例3：摘要面板
+	fmt.Println("示例3：摘要面板")
+	summary := goterm.Markup("[green]✓[/] 通过  [bold]128[/]\n[red]✗[/] 失败  [bold]2[/]\n[yellow]-[/] 跳过  [bold]5[/]")
+	goterm.NewPanel("测试结果", summary).
+		SetFooter("用时 12.4s").
+		SetFooterAlign(goterm.AlignRight).
+		SetPadding(1, 2).
+		Print()
+	fmt.Println()
+
+	// 示例4：固定宽度的错误报告，内容自动换行
+	fmt.Println("示例4：错误报告")
+	report := goterm.Markup("[bold]无法读取配置文件[/]\n\n") +
+		"open /etc/myapp/config.yaml: permission denied. " +
+		"请检查文件权限，或者使用 --config 指定其他配置文件。详见 https://example.com/docs/configuration"
+	goterm.NewPanel("错误", report).
+		SetBorder(goterm.BorderThick).
+		SetBorderStyle(goterm.New().Red()).
+		SetTitleStyle(goterm.New().Bold().Red()).
+		SetTitleAlign(goterm.AlignCenter).
+		SetWidth(50).
+		SetMargin(1, 2).
+		Print()
+}
@@ -56,6 +56,10 @@ func showBarCharts() {
 	fmt.Println()
 	// 自定义样式的条形图
 	customStyledBarChart()
+
+	fmt.Println()
+	// 渐变填充的条形图
+	gradientBarChart()
 }
 
 func basicBarChart() {
@@ -99,6 +103,25 @@ func customStyledBarChart() {
 	bar.Print()
 }
 
+func gradientBarChart() {
+	fmt.Println("渐变填充的条形图:")
+	fmt.Println("----------------")
+
+	// 条形越长，末端的颜色越接近红色
+	heat := goterm.NewGradient(goterm.MustHex("#00c853"), goterm.MustHex("#ffd600"), goterm.MustHex("#d50000")).
+		SetSpace(goterm.SpaceOklab)
+
+	bar := goterm.NewBarChart().
+		SetTitle("服务器负载").
+		AddData("web-1", 35).
+		AddData("web-2", 62).
+		AddData("db-1", 88).
+		AddData("cache", 15).
+		SetGradient(heat)
+
+	bar.Print()
+}
+
 // ==================== 饼图示例 ====================
 
 func showPieCharts() {
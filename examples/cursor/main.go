@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/lllllan02/goterm"
@@ -51,4 +52,27 @@ func main() {
 		time.Sleep(200 * time.Millisecond)
 	}
 	fmt.Println()
+
+	// 查询光标位置
+	if row, col, err := cursor.Position(); err == nil {
+		fmt.Printf("Cursor is at row %d, column %d\n", row, col)
+	}
+
+	// 改变光标形状
+	cursor.SetShape(goterm.CursorShapeBlinkingBar)
+	fmt.Print("Bar cursor")
+	time.Sleep(time.Second)
+	cursor.SetShape(goterm.CursorShapeDefault)
+
+	// 清除整行并回到行首
+	cursor.ClearEntireLine()
+	cursor.MoveToColumn(1)
+	fmt.Println("Line replaced")
+
+	// 通过输出到 stderr 的终端控制光标
+	term := goterm.NewTerminal(os.Stderr)
+	term.Print("Written to stderr")
+	term.MoveLeft(6)
+	term.ClearLine()
+	term.Println("(cleared)")
 }
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/lllllan02/goterm"
+)
+
+func main() {
+	term := goterm.DefaultTerminal()
+
+	// 全屏会话：退出后恢复原来的屏幕内容，滚动历史不受影响
+	err := term.RunFullScreen(func(fs *goterm.FullScreen) error {
+		// 按 q 退出（Ctrl-C 也可以退出，终端会被自动恢复）
+		quit := make(chan struct{})
+		go func() {
+			buf := make([]byte, 1)
+			for {
+				if _, err := term.Input().Read(buf); err != nil || buf[0] == 'q' {
+					close(quit)
+					return
+				}
+			}
+		}()
+
+		title := goterm.New().Bold().Underline()
+		cpu := goterm.NewGradient(goterm.MustHex("#00c853"), goterm.MustHex("#ffd600"), goterm.MustHex("#d50000"))
+
+		ticker := time.NewTicker(500 * time.Millisecond)
+		defer ticker.Stop()
+
+		// 每一帧先绘制到内存中的屏幕，再只输出与上一帧不同的部分，刷新时不会闪烁
+		fs.Clear()
+		screen := term.NewScreenRenderer()
+		frame := goterm.NewScreen(term.Width(), 8)
+
+		for i := 0; i < 20; i++ {
+			frame.Clear()
+			frame.SetLine(0, term.Sprint(title, "服务器状态面板"))
+			for row, name := range []string{"web-1", "web-2", "db-1", "cache"} {
+				load := rand.Intn(100)
+				frame.SetLine(row+2, fmt.Sprintf("%-6s %s %3d%%", name, cpu.Sprint(strings.Repeat("█", load/4)), load))
+			}
+			frame.SetLine(7, term.Sprint(goterm.New().Faint(), fmt.Sprintf("第 %d 次刷新，按 q 退出", i+1)))
+			screen.Render(frame)
+
+			select {
+			case <-quit:
+				return nil
+			case <-ticker.C:
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Println("无法进入全屏模式:", err)
+		return
+	}
+
+	fmt.Println("已退出全屏模式，原来的屏幕内容保持不变")
+}
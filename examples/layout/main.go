@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lllllan02/goterm"
+)
+
+func main() {
+	data := map[string]int{"Go": 45, "Rust": 25, "Python": 20, "其他": 10}
+
+	// 示例1：饼图和数据表格并排显示
+	fmt.Println("示例1：饼图和数据表格并排显示")
+	pie := goterm.NewPieChart().SetTitle("语言占比").SetData(data).SetSize(12)
+
+	table := goterm.NewEmptyTable()
+	table.AddColumn(goterm.NewColumn("语言"))
+	table.AddColumn(goterm.NewColumn("占比").SetAlignment(goterm.AlignRight))
+	for _, name := range []string{"Go", "Rust", "Python", "其他"} {
+		table.AddRow(name, fmt.Sprintf("%d%%", data[name]))
+	}
+
+	fmt.Println(goterm.JoinHorizontal(goterm.AlignMiddle, 4, pie.String(), table.StyledString()))
+	fmt.Println()
+
+	// 示例2：两栏报告
+	fmt.Println("示例2：两栏报告")
+	tree := goterm.NewTree("服务", nil)
+	api := tree.Root.AddChild("api", "运行中")
+	api.AddChild("实例", 3)
+	tree.Root.AddChild("worker", "运行中")
+	tree.Root.AddChild("cron", "已停止")
+
+	left := goterm.NewPanel("部署", tree.String()).SetWidth(30)
+	right := goterm.NewPanel("告警", goterm.Markup("[red]✗[/] cron 已停止\n[yellow]![/] 磁盘使用率 85%")).SetWidth(30)
+	footer := goterm.Markup("[faint]更新时间 2024-01-01 12:00[/]")
+
+	report := goterm.JoinVertical(goterm.AlignCenter, 1,
+		goterm.JoinHorizontal(goterm.AlignTop, 2, left.String(), right.String()),
+		footer,
+	)
+	fmt.Println(report)
+	fmt.Println()
+
+	// 示例3：不同的对齐方式
+	fmt.Println("示例3：不同的对齐方式")
+	tall := goterm.NewBox("1\n2\n3\n4\n5").String()
+	for _, align := range []goterm.VerticalAlignment{goterm.AlignTop, goterm.AlignMiddle, goterm.AlignBottom} {
+		fmt.Println(goterm.JoinHorizontal(align, 1, tall, goterm.NewBox("短").String()))
+	}
+	fmt.Println(goterm.JoinVertical(goterm.AlignRight, 0, "右对齐", "较长的一行文本", goterm.NewBox("方框").String()))
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lllllan02/goterm"
+)
+
+const document = "# goterm\n" +
+	"\n" +
+	"一个用于构建**终端界面**的 Go 工具库，支持 *样式*、~~旧的~~ `Markup` 标记、图表和交互式组件。" +
+	"项目主页见 [GitHub](https://github.com/lllllan02/goterm)，文档见 <https://pkg.go.dev/github.com/lllllan02/goterm>。\n" +
+	"\n" +
+	"## 安装\n" +
+	"\n" +
+	"```bash\n" +
+	"go get github.com/lllllan02/goterm\n" +
+	"```\n" +
+	"\n" +
+	"## 功能\n" +
+	"\n" +
+	"- 文本样式\n" +
+	"  - 16 色、256 色和真彩色\n" +
+	"  - 超链接\n" +
+	"- 组件\n" +
+	"  1. 表格\n" +
+	"  2. 进度条\n" +
+	"- [x] 主题\n" +
+	"- [ ] 更多组件\n" +
+	"\n" +
+	"> **提示**：输出重定向到文件时，样式会被自动去掉，\n" +
+	"> 超链接显示为 `文本 (地址)` 的形式。\n" +
+	"\n" +
+	"### 颜色能力\n" +
+	"\n" +
+	"| 配置 | 颜色数 | 说明 |\n" +
+	"|:-----|------:|:----:|\n" +
+	"| `ProfileANSI16` | 16 | 基本颜色 |\n" +
+	"| `ProfileANSI256` | 256 | 扩展颜色 |\n" +
+	"| `ProfileTrueColor` | 16777216 | **24 位**真彩色 |\n" +
+	"\n" +
+	"---\n" +
+	"\n" +
+	"MIT 许可证\n"
+
+func main() {
+	// 示例1：按照终端宽度渲染
+	fmt.Println("示例1：按照终端宽度渲染")
+	goterm.NewMarkdownRenderer().Print(document)
+	fmt.Println()
+
+	// 示例2：固定宽度和主题
+	fmt.Println("示例2：固定宽度和高对比度主题")
+	goterm.NewMarkdownRenderer().
+		SetWidth(40).
+		SetTheme(goterm.HighContrastTheme).
+		Print(document)
+	fmt.Println()
+
+	// 示例3：渲染为字符串，与其他组件组合
+	fmt.Println("示例3：渲染为字符串")
+	help := goterm.NewMarkdownRenderer().SetWidth(36).Render("**用法**：`app [flags]`\n\n- `-v` 显示版本\n- `-h` 显示帮助")
+	goterm.NewPanel("帮助", help).Print()
+}
@@ -18,6 +18,16 @@ func main() {
 	showStyledProgressBar()
 	fmt.Println()
 
+	// 演示自动适应终端宽度的进度条
+	fmt.Println("自动适应终端宽度的进度条（可以尝试调整窗口大小）：")
+	showAutoWidthProgressBar()
+	fmt.Println()
+
+	// 演示渐变填充的进度条
+	fmt.Println("渐变填充的进度条：")
+	showGradientProgressBar()
+	fmt.Println()
+
 	// 演示旋转指示器
 	fmt.Println("旋转指示器：")
 	showSpinner()
@@ -79,6 +89,39 @@ func showStyledProgressBar() {
 	bar.Finish()
 }
 
+// 自动适应终端宽度的进度条
+func showAutoWidthProgressBar() {
+	bar := goterm.NewProgressBar(100)
+	bar.SetPrefix("下载")
+	bar.SetWidth(0)
+
+	// 模拟进度
+	for i := 0; i <= 100; i += 2 {
+		bar.Set(int64(i))
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// 完成进度条
+	bar.Finish()
+}
+
+// 渐变填充的进度条
+func showGradientProgressBar() {
+	// 进度越接近完成，末端的颜色越接近红色
+	bar := goterm.NewProgressBar(100)
+	bar.SetWidth(40)
+	bar.SetGradient(goterm.NewGradient(goterm.MustHex("#00ff00"), goterm.MustHex("#ff0000")))
+
+	// 模拟进度
+	for i := 0; i <= 100; i += 5 {
+		bar.Set(int64(i))
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// 完成进度条
+	bar.Finish()
+}
+
 // 旋转指示器
 func showSpinner() {
 	// 创建一个旋转指示器
@@ -139,9 +182,6 @@ func showStickyProgressBar() {
 	bar.SetStyle(goterm.New().Yellow())
 	bar.SetWidth(40)
 
-	// 设置最大显示日志行数
-	bar.SetMaxLogLines(20)
-
 	// 启动进度条
 	stop := bar.Start()
 	defer stop()
@@ -195,9 +235,6 @@ func showStickyProgressBarWithExistingLogger() {
 	bar.SetStyle(goterm.New().Yellow())
 	bar.SetWidth(40)
 
-	// 设置最大显示日志行数
-	bar.SetMaxLogLines(20)
-
 	// 获取日志写入器
 	logWriter := bar.GetLogWriter()
 
@@ -273,7 +310,6 @@ func showSimplifiedLoggerWithProgressBar() {
 	bar.SetPrefix("处理任务")
 	bar.SetStyle(goterm.New().Yellow())
 	bar.SetWidth(40)
-	bar.SetMaxLogLines(20)
 
 	// 将进度条设置为活跃进度条
 	bar.SetAsActive()
@@ -39,6 +39,12 @@ func main() {
 	goterm.New().Italic().Green().Println("斜体绿色文本")
 	goterm.New().Underline().Blue().Println("下划线蓝色文本")
 	goterm.New().White().BgBlue().Println("白色文本蓝色背景")
+	goterm.New().Strikethrough().Println("删除线文本")
+	goterm.New().Reverse().Println("反色文本")
+	goterm.New().Blink().Red().Println("闪烁红色文本")
+	goterm.New().Overline().Println("上划线文本")
+	goterm.New().DoubleUnderline().Println("双下划线文本")
+	goterm.New().CurlyUnderline().UnderlineRGB(255, 0, 0).Println("红色波浪下划线文本")
 
 	// 自定义样式
 	fmt.Println("\n自定义样式:")
@@ -47,4 +53,51 @@ func main() {
 
 	errorStyle.Println("自定义错误样式")
 	warnStyle.Println("自定义警告样式")
+
+	// 颜色
+	fmt.Println("\n颜色:")
+	brand := goterm.MustHex("#ff8800")
+	goterm.New().Fg(brand).Println("品牌色")
+	goterm.New().Fg(brand.Lighten(0.2)).Println("品牌色（提亮）")
+	goterm.New().Fg(brand.Darken(0.2)).Println("品牌色（加深）")
+	goterm.New().Fg(goterm.HSL(210, 0.6, 0.5)).Println("HSL 颜色")
+	goterm.New().Fg(goterm.ANSIColor(208)).Println("256 色调色板颜色")
+	for _, name := range []string{"#1e1e1e", "#f5f5f5", "rebeccapurple", "gold"} {
+		bg, _ := goterm.ParseColor(name)
+		goterm.New().Bg(bg).Fg(bg.ReadableForeground()).Printf(" %-13s ", name)
+	}
+	fmt.Println()
+
+	// 自适应颜色
+	fmt.Println("\n自适应颜色:")
+	if goterm.DefaultRenderer().HasDarkBackground() {
+		fmt.Println("检测到深色背景")
+	} else {
+		fmt.Println("检测到浅色背景")
+	}
+	goterm.New().AdaptiveFg(goterm.AdaptiveColor{
+		Light: goterm.MustHex("#8a5a00"),
+		Dark:  goterm.ANSIColor(3),
+	}).Println("在浅色背景下使用深黄色，在深色背景下使用黄色")
+
+	// 渐变
+	fmt.Println("\n渐变:")
+	fmt.Println(goterm.Gradient("从绿色渐变到红色的文本", goterm.MustHex("#00ff00"), goterm.MustHex("#ff0000")))
+	fmt.Println(goterm.MultiGradient("经过多个颜色节点的彩虹渐变文本",
+		goterm.MustHex("#ff0000"), goterm.MustHex("#ffff00"), goterm.MustHex("#00ff00"),
+		goterm.MustHex("#00ffff"), goterm.MustHex("#0000ff"), goterm.MustHex("#ff00ff")))
+	oklab := goterm.NewGradient(goterm.MustHex("#0000ff"), goterm.MustHex("#ffff00")).SetSpace(goterm.SpaceOklab)
+	fmt.Println(oklab.Sprint("在 Oklab 空间中插值的渐变文本"))
+
+	// 超链接
+	fmt.Println("\n超链接:")
+	fmt.Println(goterm.Hyperlink("GoTerm 仓库", "https://github.com/lllllan02/goterm"))
+	goterm.New().Blue().Underline().Link("https://github.com/lllllan02/goterm/issues").Println("问题反馈")
+	fmt.Println(goterm.Markup("详见 [link=https://github.com/lllllan02/goterm/blob/main/README.md]README[/]"))
+
+	// 样式标记
+	fmt.Println("\n样式标记:")
+	fmt.Println(goterm.Markup("[bold red]Error:[/] 文件 [underline]%s[/] 不存在", "config.yaml"))
+	fmt.Println(goterm.Markup("[green]外层 [bold]嵌套粗体[/] 外层[/] 普通"))
+	fmt.Println(goterm.Markup("[#ff8800 on rgb(30,30,30)]橙色文本[/] [[字面量方括号]"))
 }
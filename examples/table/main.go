@@ -66,15 +66,15 @@ func showBorderlessTable() {
 // 显示蓝色边框表格
 func showBlueTable() {
 	table := createBasicTable()
-	table.Border.Blue()
+	table.Border = table.Border.Blue()
 	table.PrintWithStyle()
 }
 
 // 显示绿色内容表格
 func showGreenContentTable() {
 	table := createBasicTable()
-	table.Border.Blue()
-	table.Header.Green().Bold()
+	table.Border = table.Border.Blue()
+	table.Header = table.Header.Green().Bold()
 	table.PrintWithStyle()
 }
 
@@ -85,7 +85,7 @@ func showTitledTable() {
 	titleStyle := goterm.New().Magenta().Bold().Underline()
 
 	// 设置青色边框
-	table.Border.Cyan()
+	table.Border = table.Border.Cyan()
 
 	goterm.PrintStyledTable(table, title, titleStyle, nil)
 }
@@ -126,8 +126,8 @@ func showMaxWidthTable() {
 	)
 
 	// 设置样式并打印
-	table.Border.RGB(100, 100, 255)
-	table.Header.RGB(255, 100, 200).Bold()
+	table.Border = table.Border.RGB(100, 100, 255)
+	table.Header = table.Header.RGB(255, 100, 200).Bold()
 	table.PrintWithStyle()
 }
 
@@ -143,9 +143,9 @@ func showStyledTable() {
 	table.AddRow("003", "示例项目 C", "未开始")
 
 	// 设置不同部分的样式
-	table.Border.Blue()          // 蓝色边框
-	table.Header.Yellow().Bold() // 黄色粗体表头
-	table.Row.Green()            // 绿色行数据
+	table.Border = table.Border.Blue()          // 蓝色边框
+	table.Header = table.Header.Yellow().Bold() // 黄色粗体表头
+	table.Row = table.Row.Green()               // 绿色行数据
 
 	// 打印表格
 	table.PrintWithStyle()
@@ -165,9 +165,9 @@ func showRowSeparatorTable() {
 	table.AddRow("005", "示例项目 E", "已暂停")
 
 	// 设置样式
-	table.Border.Red()             // 红色边框
-	table.Header.Cyan().Bold()     // 青色表头
-	table.SetHasRowSeparator(true) // 显示行分隔线
+	table.Border = table.Border.Red()         // 红色边框
+	table.Header = table.Header.Cyan().Bold() // 青色表头
+	table.SetHasRowSeparator(true)            // 显示行分隔线
 
 	// 打印表格
 	table.PrintWithStyle()
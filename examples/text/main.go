@@ -42,4 +42,56 @@ func main() {
 		"段落格式化",
 		"列表格式化",
 	}, "→", 4))
+	fmt.Println()
+
+	// 示例6：按宽度换行
+	fmt.Println("示例6：按宽度换行")
+	text := goterm.Markup("The [bold]quick brown fox jumps over the lazy dog[/] and keeps running " +
+		"until it reaches https://example.com/a/very/long/path/to/the/forest, where it finally rests.")
+	for _, align := range []goterm.Alignment{goterm.AlignLeft, goterm.AlignRight, goterm.AlignCenter, goterm.AlignJustify} {
+		wrapper := goterm.NewTextFormatter().
+			SetWidth(40).
+			SetAlignment(align).
+			SetHangingIndent(2).
+			SetWordBreak(goterm.WordBreakHyphen)
+		fmt.Println(wrapper.Paragraph(text, 2))
+		fmt.Println()
+	}
+
+	// 示例7：中文换行与列表换行
+	fmt.Println("示例7：中文换行与列表换行")
+	wrapper := goterm.NewTextFormatter().SetWidth(30).SetAlignment(goterm.AlignJustify)
+	fmt.Println(wrapper.Paragraph("这是一个段落示例，中文可以在任意两个字之间换行，句号、逗号和右括号（例如这里）不会出现在行首。", 2))
+	fmt.Println(wrapper.List([]string{
+		"较长的列表项换行后与列表符号后的文本对齐，不会顶格显示",
+		"第二项",
+	}, "•", 2))
+	fmt.Println()
+
+	// 示例8：嵌套列表
+	fmt.Println("示例8：嵌套列表")
+	list := goterm.NewList(goterm.ListBullet).SetMarkerStyle(goterm.New().Cyan())
+	fruits := list.Add("水果")
+	fruits.Add("苹果").Add("红富士")
+	fruits.Add("香蕉")
+
+	steps := list.Add("安装步骤").AddList(goterm.ListNumbered).SetNumbering(goterm.NumberOutline)
+	prepare := steps.Add("准备环境，这一项比较长，在窄的终端中换行后与编号后的文本对齐")
+	prepare.Add("安装 Go")
+	prepare.Add("配置代理")
+	steps.Add("下载依赖")
+
+	appendix := list.Add("附录").AddList(goterm.ListNumbered).SetNumbering(goterm.NumberUpperRoman)
+	appendix.Add("术语表")
+	appendix.Add("参考资料")
+
+	todo := list.Add("待办事项").AddList(goterm.ListTask)
+	todo.AddTask("编写文档", true)
+	todo.AddTask("发布版本", false)
+
+	terms := list.Add("术语").AddList(goterm.ListDefinition)
+	terms.AddDefinition("TTY", "终端设备，goterm 根据它判断是否输出颜色和交互式组件。")
+	terms.AddDefinition("SGR", "选择图形再现（Select Graphic Rendition），用于设置文本样式的转义序列。")
+
+	fmt.Println(goterm.NewTextFormatter().SetWidth(40).FormatList(list, 2))
 }
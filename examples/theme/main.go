@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lllllan02/goterm"
+)
+
+func main() {
+	themes := []struct {
+		name  string
+		theme *goterm.Theme
+	}{
+		{"默认主题", goterm.DefaultTheme},
+		{"高对比度主题", goterm.HighContrastTheme},
+		{"单色主题", goterm.MonochromeTheme},
+		{"浅色背景主题", goterm.LightTheme},
+	}
+
+	for _, item := range themes {
+		fmt.Printf("=== %s ===\n\n", item.name)
+
+		// 设置全局主题，所有组件都会使用该主题
+		goterm.SetTheme(item.theme)
+
+		// 日志
+		fmt.Println(goterm.Error("这是一条错误信息"))
+		fmt.Println(goterm.Success("这是一条成功信息"))
+		fmt.Println(goterm.Warning("这是一条警告信息"))
+		fmt.Println()
+
+		// 条形图
+		chart := goterm.NewBarChart().
+			SetTitle("月度销售").
+			SetWidth(30).
+			AddData("一月", 120).
+			AddData("二月", 80).
+			AddData("三月", 150)
+		chart.Print()
+		fmt.Println()
+	}
+
+	// 在内置主题的基础上修改部分样式
+	custom := goterm.DefaultTheme.Copy()
+	custom.Accent = goterm.New().Magenta()
+	custom.Title = goterm.New().Bold().Magenta()
+	goterm.SetTheme(goterm.DefaultTheme)
+
+	// 只为单个组件设置主题
+	fmt.Println("=== 单个组件使用自定义主题 ===")
+	fmt.Println()
+	goterm.NewBarChart().
+		SetTitle("自定义主题").
+		SetWidth(30).
+		SetTheme(custom).
+		AddData("A", 3).
+		AddData("B", 5).
+		Print()
+}
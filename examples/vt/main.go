@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lllllan02/goterm"
+	"github.com/lllllan02/goterm/vt"
+)
+
+func main() {
+	// 创建一个 40 列 8 行的虚拟终端，组件的输出写入其中
+	emulator := vt.NewEmulator(40, 8)
+	term := emulator.Terminal()
+
+	// 表格
+	table := goterm.NewEmptyTable()
+	table.AddColumn(goterm.NewColumn("名称"))
+	table.AddColumn(goterm.NewColumn("状态"))
+	table.AddRow("web", "运行中")
+	table.Fprint(term)
+
+	// 进度条：多次刷新只留下最终的画面
+	bar := goterm.NewProgressBar(10).SetWidth(10).SetTerminal(term)
+	for i := 0; i < 10; i++ {
+		bar.Increment()
+	}
+	bar.Finish()
+
+	// 屏幕快照
+	fmt.Println("屏幕内容：")
+	fmt.Println(emulator.String())
+
+	// 光标位置和单元格样式
+	x, y := emulator.Cursor()
+	fmt.Printf("\n光标位置: 第 %d 列，第 %d 行\n", x, y)
+
+	term.Print(term.Sprint(goterm.New().Bold().Red(), "错误"))
+	cell := emulator.Cell(0, y)
+	fmt.Printf("单元格 %q: 前景色 %s，粗体 %v\n", cell.Rune, cell.Style.Fg, cell.Style.Bold)
+}
@@ -0,0 +1,231 @@
+package goterm
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SetScrollRegion 将屏幕的滚动区域设置为第 top 行到第 bottom 行（从 1 开始），
+// 区域外的行不会随输出滚动。设置后光标会移动到屏幕左上角
+func (t *Terminal) SetScrollRegion(top, bottom int) {
+	t.Printf("\033[%d;%dr", top, bottom)
+}
+
+// ResetScrollRegion 将滚动区域恢复为整个屏幕。设置后光标会移动到屏幕左上角
+func (t *Terminal) ResetScrollRegion() {
+	t.Print("\033[r")
+}
+
+// 保存和恢复光标位置（DECSC/DECRC），设置滚动区域会移动光标，因此需要在前后保存和恢复
+const (
+	saveCursor    = "\0337"
+	restoreCursor = "\0338"
+)
+
+// StickyFooter 表示固定在终端底部的区域：通过滚动区域（DECSTBM）把底部若干行保留给进度条或状态信息，
+// 其余输出在上方的区域中正常滚动并进入终端的滚动历史。
+// 使用结束后必须调用 Close 恢复滚动区域，收到 SIGINT 或 SIGTERM 时会自动恢复
+type StickyFooter struct {
+	term       *Terminal     // 底部区域所在的终端
+	height     int           // 保留的行数
+	lines      []string      // 底部区域每一行的内容
+	rows       int           // 上次布局时终端的行数，为 0 时表示没有设置滚动区域
+	cleanup    *cleanupEntry // 恢复滚动区域的操作
+	stopResize func()        // 取消终端尺寸变化订阅
+	closed     bool          // 是否已经关闭
+	mutex      sync.Mutex    // 互斥锁
+}
+
+// NewStickyFooter 在终端底部保留 height 行（至少 1 行）。
+// 输出目标不是终端或终端高度不足时不保留区域：输出直接写入终端，底部区域的内容在 Close 时输出
+func (t *Terminal) NewStickyFooter(height int) *StickyFooter {
+	if height < 1 {
+		height = 1
+	}
+	f := &StickyFooter{
+		term:   t,
+		height: height,
+		lines:  make([]string, height),
+	}
+
+	if !t.IsTerminal() {
+		return f
+	}
+	_, rows, err := t.Size()
+	if err != nil || rows <= height {
+		return f
+	}
+
+	// 先输出换行为底部区域腾出空间（光标位于屏幕底部时内容会向上滚动），再回到原来的行
+	t.Print(strings.Repeat("\n", height))
+	t.MoveUp(height)
+	f.layout(rows)
+
+	f.cleanup = registerCleanup(f.teardown)
+	f.stopResize = t.OnResize(func(columns, rows int) {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		if !f.closed {
+			f.relayout(rows)
+		}
+	})
+	return f
+}
+
+// Terminal 返回底部区域所在的终端
+func (f *StickyFooter) Terminal() *Terminal {
+	return f.term
+}
+
+// Height 返回底部区域保留的行数
+func (f *StickyFooter) Height() int {
+	return f.height
+}
+
+// Set 设置底部区域的内容并重新绘制，每个参数占一行，多余的行被忽略，缺少的行为空行。
+// 超出终端宽度的内容会被截断，避免换行破坏布局
+func (f *StickyFooter) Set(lines ...string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for i := range f.lines {
+		f.lines[i] = ""
+		if i < len(lines) {
+			f.lines[i] = lines[i]
+		}
+	}
+	if f.rows > 0 {
+		f.draw()
+	}
+}
+
+// SetLine 设置底部区域第 i 行（从 0 开始）的内容并重新绘制
+func (f *StickyFooter) SetLine(i int, line string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if i < 0 || i >= len(f.lines) {
+		return
+	}
+	f.lines[i] = line
+	if f.rows > 0 {
+		f.draw()
+	}
+}
+
+// Write 实现 io.Writer 接口，内容输出到底部区域上方的滚动区域
+func (f *StickyFooter) Write(p []byte) (n int, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.term.Write(p)
+}
+
+// Println 在底部区域上方输出一行文本，文本随后进入终端的滚动历史
+func (f *StickyFooter) Println(a ...any) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.term.Println(a...)
+}
+
+// Printf 在底部区域上方输出格式化文本
+func (f *StickyFooter) Printf(format string, a ...any) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.term.Printf(format, a...)
+}
+
+// Close 清除底部区域并恢复滚动区域（多次调用只执行一次）。
+// 没有保留区域时（例如输出目标不是终端）输出底部区域中的非空行
+func (f *StickyFooter) Close() error {
+	if f.cleanup != nil {
+		return f.cleanup.run()
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, line := range f.lines {
+		if line != "" {
+			f.term.Println(line)
+		}
+	}
+	f.lines = make([]string, f.height)
+	return nil
+}
+
+// teardown 取消尺寸变化订阅，清除底部区域并恢复滚动区域
+func (f *StickyFooter) teardown() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.closed = true
+	if f.stopResize != nil {
+		f.stopResize()
+		f.stopResize = nil
+	}
+	if f.rows == 0 {
+		return nil
+	}
+
+	t := f.term
+	t.Print(saveCursor)
+	f.clear(f.rows)
+	t.ResetScrollRegion()
+	t.Print(restoreCursor)
+	f.rows = 0
+	return nil
+}
+
+// layout 按照终端的行数设置滚动区域，光标位置保持不变
+func (f *StickyFooter) layout(rows int) {
+	t := f.term
+	t.Print(saveCursor)
+	t.SetScrollRegion(1, rows-f.height)
+	t.Print(restoreCursor)
+	f.rows = rows
+}
+
+// relayout 在终端尺寸变化后重新设置滚动区域并绘制底部区域
+func (f *StickyFooter) relayout(rows int) {
+	t := f.term
+	if rows <= f.height {
+		// 终端太矮时暂时放弃保留区域，输出恢复正常滚动，终端变高后重新保留
+		t.Print(saveCursor)
+		t.ResetScrollRegion()
+		t.Print(restoreCursor)
+		f.rows = 0
+		return
+	}
+
+	// 终端变高时原来的底部区域位于滚动区域内，需要先清除
+	if f.rows > 0 && rows > f.rows {
+		t.Print(saveCursor)
+		f.clear(f.rows)
+		t.Print(restoreCursor)
+	}
+	f.layout(rows)
+	f.draw()
+}
+
+// draw 在屏幕底部绘制底部区域的内容，光标位置保持不变
+func (f *StickyFooter) draw() {
+	t := f.term
+	var out strings.Builder
+	out.WriteString(saveCursor)
+	for i, line := range f.lines {
+		fmt.Fprintf(&out, "\033[%d;1H\033[2K", f.rows-f.height+1+i)
+		out.WriteString(fitLine(t, line))
+	}
+	out.WriteString(restoreCursor)
+
+	// 一次写入，避免绘制到一半时被其他输出打断
+	t.Print(out.String())
+}
+
+// clear 清除终端行数为 rows 时底部区域所在的行
+func (f *StickyFooter) clear(rows int) {
+	for i := 0; i < f.height; i++ {
+		f.term.MoveTo(rows-f.height+1+i, 1)
+		f.term.ClearEntireLine()
+	}
+}
@@ -0,0 +1,88 @@
+package goterm
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// FullScreen 表示一个全屏会话：使用备用屏幕、隐藏光标并开启原始模式，
+// 退出后恢复原来的屏幕内容，用户的滚动历史不受影响
+type FullScreen struct {
+	term    *Terminal     // 全屏会话所在的终端
+	cleanup *cleanupEntry // 退出全屏会话的恢复操作
+}
+
+// EnterAltScreen 切换到备用屏幕
+func (t *Terminal) EnterAltScreen() {
+	t.Print("\033[?1049h")
+}
+
+// ExitAltScreen 离开备用屏幕，恢复原来的屏幕内容
+func (t *Terminal) ExitAltScreen() {
+	t.Print("\033[?1049l")
+}
+
+// EnterFullScreen 进入全屏会话，使用保留信号的原始模式（Ctrl-C 仍然可以终止程序）。
+// 信号的处理方式见 EnterFullScreenMode
+func (t *Terminal) EnterFullScreen() (*FullScreen, error) {
+	return t.EnterFullScreenMode(RawModeSignals)
+}
+
+// EnterFullScreenMode 使用指定的原始模式进入全屏会话。
+// 输入源不是终端设备时（例如输入被重定向）不开启原始模式。
+// 处于全屏会话时收到 SIGINT 或 SIGTERM 会先退出全屏会话，再重新发送该信号；
+// 程序自己处理这些信号时（例如退出前询问确认）应该将 RestoreOnSignal 设置为 false，
+// 并在处理完成后调用 Exit
+func (t *Terminal) EnterFullScreenMode(mode RawMode) (*FullScreen, error) {
+	var restoreRaw func() error
+	if f, ok := t.in.(*os.File); ok && isatty.IsTerminal(f.Fd()) {
+		restore, err := t.MakeRawMode(mode)
+		if err != nil {
+			return nil, err
+		}
+		restoreRaw = restore
+	}
+
+	t.EnterAltScreen()
+	t.HideCursor()
+	t.ClearScreen()
+	t.MoveTo(1, 1)
+
+	fs := &FullScreen{term: t}
+	fs.cleanup = registerCleanup(func() error {
+		t.ShowCursor()
+		t.ExitAltScreen()
+		if restoreRaw != nil {
+			return restoreRaw()
+		}
+		return nil
+	})
+	return fs, nil
+}
+
+// RunFullScreen 在全屏会话中执行 fn，fn 返回或 panic 时都会退出全屏会话
+func (t *Terminal) RunFullScreen(fn func(fs *FullScreen) error) error {
+	fs, err := t.EnterFullScreen()
+	if err != nil {
+		return err
+	}
+	defer fs.Exit()
+	return fn(fs)
+}
+
+// Terminal 返回全屏会话所在的终端
+func (fs *FullScreen) Terminal() *Terminal {
+	return fs.term
+}
+
+// Clear 清除屏幕并将光标移动到左上角
+func (fs *FullScreen) Clear() {
+	fs.term.ClearScreen()
+	fs.term.MoveTo(1, 1)
+}
+
+// Exit 退出全屏会话：显示光标、离开备用屏幕并恢复终端模式（多次调用只执行一次）
+func (fs *FullScreen) Exit() error {
+	return fs.cleanup.run()
+}
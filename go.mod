@@ -2,6 +2,7 @@ module github.com/lllllan02/goterm
 
 go 1.18
 
-require github.com/mattn/go-isatty v0.0.20
-
-require golang.org/x/sys v0.6.0 // indirect
+require (
+	github.com/mattn/go-isatty v0.0.20
+	golang.org/x/sys v0.6.0
+)
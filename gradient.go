@@ -0,0 +1,226 @@
+package goterm
+
+import (
+	"io"
+	"math"
+	"strings"
+)
+
+// ColorSpace 表示渐变插值使用的颜色空间
+type ColorSpace int
+
+// 渐变插值的颜色空间
+const (
+	SpaceRGB   ColorSpace = iota // 在 sRGB 空间中线性插值
+	SpaceOklab                   // 在 Oklab 感知均匀空间中插值，中间色的亮度过渡更自然
+)
+
+// ColorGradient 表示由多个颜色节点组成的渐变，节点均匀分布在 0-1 范围内
+type ColorGradient struct {
+	Stops []Color    // 颜色节点
+	Space ColorSpace // 插值使用的颜色空间
+}
+
+// NewGradient 使用多个颜色节点创建渐变（默认在 RGB 空间中插值）
+func NewGradient(stops ...Color) *ColorGradient {
+	return &ColorGradient{
+		Stops: stops,
+		Space: SpaceRGB,
+	}
+}
+
+// SetSpace 设置插值使用的颜色空间
+func (g *ColorGradient) SetSpace(space ColorSpace) *ColorGradient {
+	g.Space = space
+	return g
+}
+
+// At 返回渐变中位置 t（0-1）处的颜色
+func (g *ColorGradient) At(t float64) Color {
+	switch len(g.Stops) {
+	case 0:
+		return Color{}
+	case 1:
+		return g.Stops[0]
+	}
+
+	// 找到 t 所在的两个节点
+	t = clampUnit(t)
+	segment := t * float64(len(g.Stops)-1)
+	i := int(segment)
+	if i >= len(g.Stops)-1 {
+		return g.Stops[len(g.Stops)-1]
+	}
+	from, to := g.Stops[i], g.Stops[i+1]
+	local := segment - float64(i)
+
+	if g.Space == SpaceOklab {
+		return blendOklab(from, to, local)
+	}
+	return from.Blend(to, local)
+}
+
+// Colors 返回在渐变上均匀取样的 n 个颜色
+func (g *ColorGradient) Colors(n int) []Color {
+	colors := make([]Color, n)
+	for i := range colors {
+		colors[i] = g.At(gradientPosition(i, n))
+	}
+	return colors
+}
+
+// Sprint 返回每个字符按照渐变着色的字符串（使用默认渲染器的颜色能力）
+func (g *ColorGradient) Sprint(text string) string {
+	return g.render(DefaultRenderer(), nil, text)
+}
+
+// Fprint 输出每个字符按照渐变着色的文本到指定的 writer（根据该 writer 决定颜色能力）
+func (g *ColorGradient) Fprint(w io.Writer, text string) (n int, err error) {
+	return io.WriteString(w, g.render(rendererFor(w), nil, text))
+}
+
+// Print 输出每个字符按照渐变着色的文本
+func (g *ColorGradient) Print(text string) (n int, err error) {
+	return g.Fprint(Output, text)
+}
+
+// Gradient 返回从 from 渐变到 to 的字符串（使用默认渲染器的颜色能力）
+func Gradient(text string, from, to Color) string {
+	return NewGradient(from, to).Sprint(text)
+}
+
+// MultiGradient 返回依次经过多个颜色节点渐变的字符串（使用默认渲染器的颜色能力）
+func MultiGradient(text string, stops ...Color) string {
+	return NewGradient(stops...).Sprint(text)
+}
+
+// render 按照渐变为文本中的每个可见字符着色，base 为叠加在渐变颜色之下的样式。
+// 文本中已有的转义序列原样保留，降级后颜色相同的相邻字符合并输出
+func (g *ColorGradient) render(r *Renderer, base *Style, text string) string {
+	count := 0
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			i += n
+			continue
+		}
+		size, _ := nextGrapheme(text[i:])
+		count++
+		i += size
+	}
+	return g.renderSpan(r, base, text, count)
+}
+
+// fill 返回由 count 个填充字符组成、按照长度为 total 的渐变着色的字符串，
+// 用于进度条和条形图：条形越长，末端的颜色越接近渐变的终点
+func (g *ColorGradient) fill(r *Renderer, base *Style, char string, count, total int) string {
+	if count <= 0 {
+		return ""
+	}
+	return g.renderSpan(r, base, strings.Repeat(char, count), total)
+}
+
+// renderSpan 按照长度为 total 的渐变为文本中的可见字符依次着色
+func (g *ColorGradient) renderSpan(r *Renderer, base *Style, text string, total int) string {
+	if !r.HasColor() || len(g.Stops) == 0 {
+		return r.Sprint(base, text)
+	}
+
+	var (
+		result  strings.Builder
+		segment strings.Builder
+		current string // 当前片段降级后的颜色代码
+		style   *Style // 当前片段的样式
+	)
+	flush := func() {
+		if segment.Len() > 0 {
+			result.WriteString(r.Sprint(style, segment.String()))
+			segment.Reset()
+		}
+	}
+
+	profile := r.ColorProfile()
+	index := 0
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			segment.WriteString(text[i : i+n])
+			i += n
+			continue
+		}
+
+		// 按字素簇着色，组合字符和表情符号序列使用同一个颜色
+		size, _ := nextGrapheme(text[i:])
+		c := g.At(gradientPosition(index, total))
+		if code := profile.Convert(c.code("38")); code != current || style == nil {
+			flush()
+			current = code
+			style = New().Fg(c).Inherit(base)
+		}
+		segment.WriteString(text[i : i+size])
+		index++
+		i += size
+	}
+	flush()
+
+	return result.String()
+}
+
+// gradientPosition 返回 n 个均匀取样点中第 i 个点在渐变中的位置
+func gradientPosition(i, n int) float64 {
+	if n <= 1 {
+		return 0
+	}
+	return float64(i) / float64(n-1)
+}
+
+// blendOklab 在 Oklab 空间中混合两个颜色
+func blendOklab(from, to Color, t float64) Color {
+	l1, a1, b1 := toOklab(from)
+	l2, a2, b2 := toOklab(to)
+	mix := func(x, y float64) float64 { return x + (y-x)*t }
+	return fromOklab(mix(l1, l2), mix(a1, a2), mix(b1, b2))
+}
+
+// toOklab 将颜色转换到 Oklab 空间
+func toOklab(c Color) (l, a, b float64) {
+	r, g, bl := srgbToLinear(c.R), srgbToLinear(c.G), srgbToLinear(c.B)
+
+	lms1 := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*bl)
+	lms2 := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*bl)
+	lms3 := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*bl)
+
+	l = 0.2104542553*lms1 + 0.7936177850*lms2 - 0.0040720468*lms3
+	a = 1.9779984951*lms1 - 2.4285922050*lms2 + 0.4505937099*lms3
+	b = 0.0259040371*lms1 + 0.7827717662*lms2 - 0.8086757660*lms3
+	return l, a, b
+}
+
+// fromOklab 将 Oklab 空间中的颜色转换为 RGB 颜色
+func fromOklab(l, a, b float64) Color {
+	lms1 := l + 0.3963377774*a + 0.2158037573*b
+	lms2 := l - 0.1055613458*a - 0.0638541728*b
+	lms3 := l - 0.0894841775*a - 1.2914855480*b
+	lms1, lms2, lms3 = lms1*lms1*lms1, lms2*lms2*lms2, lms3*lms3*lms3
+
+	r := 4.0767416621*lms1 - 3.3077115913*lms2 + 0.2309699292*lms3
+	g := -1.2684380046*lms1 + 2.6097574011*lms2 - 0.3413193965*lms3
+	bl := -0.0041960863*lms1 - 0.7034186147*lms2 + 1.7076147010*lms3
+	return NewColor(linearToSRGB(r), linearToSRGB(g), linearToSRGB(bl))
+}
+
+// srgbToLinear 将 sRGB 分量转换为线性分量（0-1）
+func srgbToLinear(v uint8) float64 {
+	f := float64(v) / 255
+	if f <= 0.04045 {
+		return f / 12.92
+	}
+	return math.Pow((f+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB 将线性分量转换为 sRGB 分量（0-255）
+func linearToSRGB(v float64) int {
+	v = clampUnit(v)
+	if v <= 0.0031308 {
+		return unitToByte(v * 12.92)
+	}
+	return unitToByte(1.055*math.Pow(v, 1/2.4) - 0.055)
+}
@@ -0,0 +1,104 @@
+package goterm
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// OSC 8 超链接序列
+const (
+	hyperlinkStart = "\033]8;;"
+	hyperlinkEnd   = "\033\\"
+)
+
+// Hyperlink 返回链接到指定地址的文本（使用默认渲染器的能力），
+// 支持 OSC 8 的终端中文本可以点击，不支持时输出为 "文本 (地址)"
+func Hyperlink(text, url string) string {
+	return DefaultRenderer().Hyperlink(text, url)
+}
+
+// Hyperlink 返回链接到指定地址的文本，
+// 支持 OSC 8 的终端中文本可以点击，不支持时输出为 "文本 (地址)"
+func (r *Renderer) Hyperlink(text, url string) string {
+	return r.link(text, text, url)
+}
+
+// HasHyperlinks 返回渲染器是否输出 OSC 8 超链接。
+// 不输出颜色时（包括设置了 NO_COLOR）不输出超链接；
+// 可以通过 FORCE_HYPERLINK 环境变量或 SetHyperlinks 强制开启或关闭
+func (r *Renderer) HasHyperlinks() bool {
+	if r.hyperlinks != nil {
+		return *r.hyperlinks
+	}
+	if !r.HasColor() {
+		return false
+	}
+	return detectHyperlinks()
+}
+
+// SetHyperlinks 设置渲染器是否输出 OSC 8 超链接，跳过自动检测
+func (r *Renderer) SetHyperlinks(enabled bool) *Renderer {
+	r.hyperlinks = &enabled
+	return r
+}
+
+// link 为已渲染的文本添加超链接，不支持超链接时在文本之后附加地址（文本与地址相同时只输出文本）
+func (r *Renderer) link(styled, text, url string) string {
+	if r.HasHyperlinks() {
+		return hyperlinkStart + url + hyperlinkEnd + styled + hyperlinkStart + hyperlinkEnd
+	}
+	if text == url || url == "" {
+		return styled
+	}
+	return styled + " (" + url + ")"
+}
+
+// detectHyperlinks 根据环境变量判断终端是否支持 OSC 8 超链接
+func detectHyperlinks() bool {
+	if value, ok := os.LookupEnv("FORCE_HYPERLINK"); ok {
+		return value != "0" && value != "false"
+	}
+
+	// 终端复用器和 CI 环境中的支持情况不确定
+	if os.Getenv("CI") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		return false
+	}
+
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "Hyper", "ghostty", "tabby", "rio":
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KONSOLE_VERSION") != "" || os.Getenv("DOMTERM") != "" {
+		return true
+	}
+	if version, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && version >= 5000 {
+		return true
+	}
+
+	switch os.Getenv("TERM") {
+	case "xterm-kitty", "alacritty", "foot", "xterm-ghostty", "wezterm":
+		return true
+	}
+	return false
+}
+
+// hyperlinkState 判断转义序列是否为 OSC 8 超链接，以及它是开启还是关闭超链接
+func hyperlinkState(seq string) (isLink, open bool) {
+	isLink, url := hyperlinkURL(seq)
+	return isLink, url != ""
+}
+
+// hyperlinkURL 判断转义序列是否为 OSC 8 超链接，并返回其中的地址（关闭超链接时地址为空）
+func hyperlinkURL(seq string) (isLink bool, url string) {
+	if !strings.HasPrefix(seq, "\033]8;") {
+		return false, ""
+	}
+	// 格式为 ESC ] 8 ; 参数 ; 地址 终止符，地址为空表示关闭超链接
+	rest := seq[len("\033]8;"):]
+	i := strings.IndexByte(rest, ';')
+	if i < 0 {
+		return true, ""
+	}
+	return true, strings.TrimSuffix(strings.TrimSuffix(rest[i+1:], "\a"), hyperlinkEnd)
+}
@@ -3,23 +3,41 @@ package goterm
 import (
 	"bufio"
 	"fmt"
-	"os"
-	"os/exec"
+	"io"
 	"strings"
 )
 
 // Interactive 提供终端交互式组件
 type Interactive struct {
-	cursor *Cursor
-	style  *Style
+	term  *Terminal // 交互使用的终端，为空时使用默认终端
+	theme *Theme
 }
 
 // NewInteractive 创建一个新的交互式组件实例
 func NewInteractive() *Interactive {
-	return &Interactive{
-		cursor: NewCursor(),
-		style:  New(),
-	}
+	return &Interactive{}
+}
+
+// SetTerminal 设置交互使用的终端（为空时使用默认终端）
+func (i *Interactive) SetTerminal(term *Terminal) *Interactive {
+	i.term = term
+	return i
+}
+
+// terminal 返回交互使用的终端
+func (i *Interactive) terminal() *Terminal {
+	return terminalOr(i.term)
+}
+
+// SetTheme 设置交互式组件使用的主题（为空时使用全局主题）
+func (i *Interactive) SetTheme(theme *Theme) *Interactive {
+	i.theme = theme
+	return i
+}
+
+// currentTheme 返回交互式组件当前使用的主题
+func (i *Interactive) currentTheme() *Theme {
+	return themeOr(i.theme)
 }
 
 // InputField 输入框
@@ -46,14 +64,15 @@ func (input *InputField) WithDefault(defaultText string) *InputField {
 
 // ReadString 读取用户输入的字符串
 func (input *InputField) ReadString() string {
-	promptStyle := input.interactive.style.Green().Bold()
-	fmt.Print(promptStyle.Sprint(input.prompt + ": "))
+	term := input.interactive.terminal()
+	promptStyle := input.interactive.currentTheme().Prompt
+	term.Print(term.Sprint(promptStyle, input.prompt+": "))
 
 	if input.defaultText != "" {
-		fmt.Printf("[%s] ", input.defaultText)
+		term.Printf("[%s] ", input.defaultText)
 	}
 
-	reader := bufio.NewReader(os.Stdin)
+	reader := bufio.NewReader(term.Input())
 	result, _ := reader.ReadString('\n')
 	result = strings.TrimSpace(result)
 
@@ -90,92 +109,44 @@ func (i *Interactive) NewSelectField(prompt string, options []SelectOption) *Sel
 
 // Render 渲染选择框并获取用户选择
 func (selectField *SelectField) Render() SelectOption {
-	// 保存初始位置
-	promptStyle := selectField.interactive.style.Blue().Bold()
-	fmt.Println(promptStyle.Sprint(selectField.prompt))
-
-	// 初始化一次选项显示
-	for i, option := range selectField.options {
-		prefix := "  "
-		if i == selectField.selected {
-			prefix = "> "
-			fmt.Println(selectField.interactive.style.Green().Sprint(prefix + option.Label))
-		} else {
-			fmt.Println(prefix + option.Label)
-		}
-	}
+	term := selectField.interactive.terminal()
+	theme := selectField.interactive.currentTheme()
 
-	// 提示信息
-	fmt.Println(selectField.interactive.style.Faint().Sprint("(使用↑↓选择，回车确认，ESC取消)"))
-
-	// 将光标移到选项区域的起始位置
-	selectField.interactive.cursor.MoveUp(len(selectField.options) + 1) // +1 是为了提示行
+	// 通过 ScreenRenderer 绘制，每次按键只重绘发生变化的选项
+	screen := term.NewScreenRenderer()
+	screen.RenderLines(selectField.lines(term, theme)...)
 
 	// 设置终端为原始模式
-	selectField.interactive.cursor.HideCursor()
-	defer selectField.interactive.cursor.ShowCursor()
+	term.HideCursor()
+	defer term.ShowCursor()
 
 	// 将终端设置为原始模式，以便可以读取单个字符
-	oldState, err := makeRaw(int(os.Stdin.Fd()))
+	// 使用完全的原始模式，Ctrl-C 作为按键读取并按照取消处理
+	restore, err := term.MakeRaw()
 	if err != nil {
-		fmt.Println("无法设置终端为原始模式:", err)
+		screen.Finish()
+		term.Println("无法设置终端为原始模式:", err)
 		return selectField.options[selectField.selected]
 	}
-	defer restoreTerminal(int(os.Stdin.Fd()), oldState)
+	defer restore()
 
 	// 主循环：处理用户输入
 	for {
-		// 清除并重绘所有选项
-		for i, option := range selectField.options {
-			// 移动到当前选项行
-			if i > 0 {
-				selectField.interactive.cursor.MoveDown(1)
-			}
-
-			// 清除当前行
-			fmt.Print("\r")
-			selectField.interactive.cursor.ClearLine()
-
-			// 显示选项
-			prefix := "  "
-			if i == selectField.selected {
-				prefix = "> "
-				fmt.Print(selectField.interactive.style.Green().Sprint(prefix + option.Label))
-			} else {
-				fmt.Print(prefix + option.Label)
-			}
-		}
-
-		// 移动到提示行并更新
-		selectField.interactive.cursor.MoveDown(1)
-		fmt.Print("\r")
-		selectField.interactive.cursor.ClearLine()
-		fmt.Print(selectField.interactive.style.Faint().Sprint("(使用↑↓选择，回车确认，ESC取消)"))
-
-		// 移回第一个选项的位置
-		selectField.interactive.cursor.MoveUp(len(selectField.options))
-
 		// 读取按键
-		key := readKey()
+		key := readKey(term.Input())
 
 		// 处理按键
 		switch key {
-		case keyEsc:
+		case keyEsc, keyCtrlC:
 			// 选择第一个选项并退出
 			selectField.selected = 0
 
-			// 清除选择区域和提示行
-			fmt.Print("\r")
-			for i := 0; i < len(selectField.options); i++ {
-				selectField.interactive.cursor.ClearLine()
-				selectField.interactive.cursor.MoveDown(1)
-			}
-			selectField.interactive.cursor.ClearLine() // 清除提示行
-
-			// 返回到选项区域开始处并显示取消信息
-			selectField.interactive.cursor.MoveUp(len(selectField.options) + 1)
-			fmt.Printf("%s: %s\n", selectField.prompt,
-				selectField.interactive.style.Red().Sprint("已取消选择"))
+			// 用取消信息替换选项区域和提示行
+			screen.RenderLines(
+				term.Sprint(theme.Prompt, selectField.prompt),
+				fmt.Sprintf("%s: %s", selectField.prompt, term.Sprint(theme.Error, "已取消选择")),
+			)
+			screen.Finish()
 
 			// 如果没有选项，创建一个默认选项
 			if len(selectField.options) > 0 {
@@ -192,28 +163,45 @@ func (selectField *SelectField) Render() SelectOption {
 				selectField.selected++
 			}
 		case keyEnter:
-			// 清除选择区域和提示行
-			fmt.Print("\r")
-			for i := 0; i < len(selectField.options); i++ {
-				selectField.interactive.cursor.ClearLine()
-				if i < len(selectField.options)-1 {
-					selectField.interactive.cursor.MoveDown(1)
-				}
-			}
-			selectField.interactive.cursor.MoveDown(1)
-			selectField.interactive.cursor.ClearLine() // 清除提示行
-
-			// 返回到选项区域开始处并显示结果
-			selectField.interactive.cursor.MoveUp(len(selectField.options))
-			fmt.Printf("%s: %s\n", selectField.prompt,
-				selectField.interactive.style.Green().Sprint(
-					selectField.options[selectField.selected].Label))
+			// 用选择结果替换选项区域和提示行
+			screen.RenderLines(
+				term.Sprint(theme.Prompt, selectField.prompt),
+				fmt.Sprintf("%s: %s", selectField.prompt,
+					term.Sprint(theme.Selection, selectField.options[selectField.selected].Label)),
+			)
+			screen.Finish()
 
 			return selectField.options[selectField.selected]
 		}
+
+		screen.RenderLines(selectField.lines(term, theme)...)
 	}
 }
 
+// lines 返回选择框当前的内容：提示、选项和操作说明
+func (selectField *SelectField) lines(term *Terminal, theme *Theme) []string {
+	lines := []string{term.Sprint(theme.Prompt, selectField.prompt)}
+	for i, option := range selectField.options {
+		if i == selectField.selected {
+			lines = append(lines, term.Sprint(theme.Selection, fitLine(term, "> "+option.Label)))
+		} else {
+			lines = append(lines, fitLine(term, "  "+option.Label))
+		}
+	}
+	return append(lines, term.Sprint(theme.Muted, fitLine(term, "(使用↑↓选择，回车确认，ESC取消)")))
+}
+
+// fitLine 将一行文本截断到终端宽度以内，避免自动换行打乱选项区域的光标位置。
+// 每次重绘时重新获取终端宽度，因此终端尺寸变化后的下一次重绘会按照新的宽度布局
+func fitLine(term *Terminal, text string) string {
+	width := term.Width() - 1
+	if StringWidth(text) <= width {
+		return text
+	}
+	truncated, _ := truncateText(text, width)
+	return truncated
+}
+
 // DropdownMenu 下拉菜单
 type DropdownMenu struct {
 	title       string
@@ -256,14 +244,15 @@ const (
 	keyArrowLeft  = 1003
 	keyEnter      = 1004
 	keyEsc        = 1005
+	keyCtrlC      = 3
 )
 
 // readKey 读取一个按键
-func readKey() int {
+func readKey(in io.Reader) int {
 	buffer := make([]byte, 3)
 
 	// 读取第一个字节
-	n, _ := os.Stdin.Read(buffer[:1])
+	n, _ := in.Read(buffer[:1])
 	if n != 1 {
 		return 0
 	}
@@ -275,7 +264,7 @@ func readKey() int {
 
 	if buffer[0] == 27 { // ESC 键
 		// 检查是否有后续字节可读
-		n, _ = os.Stdin.Read(buffer[1:2])
+		n, _ = in.Read(buffer[1:2])
 		if n != 1 { // 单独的ESC键
 			return keyEsc
 		}
@@ -285,7 +274,7 @@ func readKey() int {
 		}
 
 		// 读取第三个字节 (表示箭头键的方向)
-		n, _ = os.Stdin.Read(buffer[2:3])
+		n, _ = in.Read(buffer[2:3])
 		if n != 1 {
 			return keyEsc
 		}
@@ -308,41 +297,3 @@ func readKey() int {
 	// 其他按键
 	return int(buffer[0])
 }
-
-// 终端状态
-type terminalState struct {
-	state []byte
-}
-
-// 将终端设置为原始模式
-func makeRaw(fd int) (*terminalState, error) {
-	var oldState terminalState
-	// 使用 stty 命令保存当前设置
-	cmd := exec.Command("stty", "-g")
-	cmd.Stdin = os.Stdin
-	out, err := cmd.Output()
-	if err != nil {
-		return nil, err
-	}
-	oldState.state = out
-
-	// 设置为原始模式
-	rawCmd := exec.Command("stty", "cbreak", "-echo")
-	rawCmd.Stdin = os.Stdin
-	err = rawCmd.Run()
-	if err != nil {
-		return nil, err
-	}
-
-	return &oldState, nil
-}
-
-// 恢复终端设置
-func restoreTerminal(fd int, oldState *terminalState) error {
-	if oldState == nil {
-		return nil
-	}
-	cmd := exec.Command("stty", string(oldState.state))
-	cmd.Stdin = os.Stdin
-	return cmd.Run()
-}
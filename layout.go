@@ -0,0 +1,123 @@
+package goterm
+
+import "strings"
+
+// VerticalAlignment 表示垂直方向的对齐方式
+type VerticalAlignment int
+
+const (
+	AlignTop    VerticalAlignment = iota // 顶部对齐
+	AlignMiddle                          // 垂直居中
+	AlignBottom                          // 底部对齐
+)
+
+// JoinHorizontal 将多个多行文本块从左到右排列，块之间间隔 gap 列（负数视为 0）。
+// 高度不同的块按照 align 在垂直方向对齐，宽度按照显示宽度计算（忽略 ANSI 转义序列）
+func JoinHorizontal(align VerticalAlignment, gap int, blocks ...string) string {
+	if len(blocks) == 0 {
+		return ""
+	}
+	if gap < 0 {
+		gap = 0
+	}
+
+	split := make([][]string, len(blocks))
+	widths := make([]int, len(blocks))
+	height := 0
+	for i, block := range blocks {
+		split[i] = blockLines(block)
+		widths[i] = blockWidth(split[i])
+		if len(split[i]) > height {
+			height = len(split[i])
+		}
+	}
+
+	lines := make([]string, height)
+	separator := strings.Repeat(" ", gap)
+	for i, block := range split {
+		offset := 0
+		switch align {
+		case AlignMiddle:
+			offset = (height - len(block)) / 2
+		case AlignBottom:
+			offset = height - len(block)
+		}
+
+		last := i == len(split)-1
+		for y := range lines {
+			line := ""
+			if y >= offset && y-offset < len(block) {
+				line = block[y-offset]
+			}
+			if i > 0 {
+				lines[y] += separator
+			}
+			// 最后一个块不需要填充到块的宽度
+			if !last {
+				line += strings.Repeat(" ", widths[i]-StringWidth(line))
+			}
+			lines[y] += line
+		}
+	}
+	for y, line := range lines {
+		lines[y] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JoinVertical 将多个多行文本块从上到下排列，块之间间隔 gap 行（负数视为 0）。
+// 宽度不同的块按照 align（左对齐、居中、右对齐）在水平方向对齐
+func JoinVertical(align Alignment, gap int, blocks ...string) string {
+	if gap < 0 {
+		gap = 0
+	}
+	var split [][]string
+	width := 0
+	for _, block := range blocks {
+		lines := blockLines(block)
+		split = append(split, lines)
+		if w := blockWidth(lines); w > width {
+			width = w
+		}
+	}
+
+	var lines []string
+	for i, block := range split {
+		if i > 0 {
+			for j := 0; j < gap; j++ {
+				lines = append(lines, "")
+			}
+		}
+		// 以块为单位对齐，块中各行的相对位置保持不变
+		indent := ""
+		switch padding := width - blockWidth(block); align {
+		case AlignCenter:
+			indent = strings.Repeat(" ", padding/2)
+		case AlignRight:
+			indent = strings.Repeat(" ", padding)
+		}
+		for _, line := range block {
+			if line != "" {
+				line = indent + line
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// blockLines 将文本块拆分为可以单独输出的行，忽略末尾的一个换行符
+func blockLines(block string) []string {
+	return styledLines(strings.TrimSuffix(block, "\n"))
+}
+
+// blockWidth 返回各行显示宽度的最大值
+func blockWidth(lines []string) int {
+	width := 0
+	for _, line := range lines {
+		if w := StringWidth(line); w > width {
+			width = w
+		}
+	}
+	return width
+}
@@ -0,0 +1,12 @@
+package goterm
+
+import "testing"
+
+func TestJoinNegativeGap(t *testing.T) {
+	if got, want := JoinHorizontal(AlignTop, -2, "a\nb", "c"), "ac\nb"; got != want {
+		t.Errorf("JoinHorizontal = %q, want %q", got, want)
+	}
+	if got, want := JoinVertical(AlignLeft, -2, "a", "b"), "a\nb"; got != want {
+		t.Errorf("JoinVertical = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,285 @@
+package goterm
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ListKind 表示列表的类型
+type ListKind int
+
+const (
+	ListBullet     ListKind = iota // 无序列表
+	ListNumbered                   // 有序列表
+	ListTask                       // 任务列表，每一项前面是 [x] 或 [ ] 复选框
+	ListDefinition                 // 定义列表，每一项是术语和缩进的描述
+)
+
+// Numbering 表示有序列表的编号方式
+type Numbering int
+
+const (
+	NumberDecimal    Numbering = iota // 1. 2. 3.
+	NumberLowerAlpha                  // a. b. c.
+	NumberUpperAlpha                  // A. B. C.
+	NumberLowerRoman                  // i. ii. iii.
+	NumberUpperRoman                  // I. II. III.
+	NumberOutline                     // 1. 1.1. 1.1.1.（包含上级有序列表的编号）
+)
+
+// DefaultBullets 无序列表各层默认使用的符号
+var DefaultBullets = []string{"•", "◦", "▪"}
+
+// definitionIndent 定义列表中描述相对术语的缩进
+const definitionIndent = 4
+
+// ListItem 表示列表中的一项
+type ListItem struct {
+	Text        string // 文本，可以包含换行符和样式；定义列表中为术语
+	Description string // 定义列表中术语的描述
+	Checked     bool   // 任务列表中是否已经完成
+	Children    *List  // 子列表
+	list        *List  // 所属的列表
+}
+
+// List 表示可以嵌套的列表
+type List struct {
+	Kind        ListKind    // 列表的类型
+	Items       []*ListItem // 列表项
+	Numbering   Numbering   // 有序列表的编号方式
+	Start       int         // 有序列表的起始编号
+	Bullets     []string    // 无序列表各层的符号（为空时使用上级列表的设置或 DefaultBullets），层数超过长度时循环使用
+	MarkerStyle *Style      // 列表符号、编号和复选框的样式
+	TermStyle   *Style      // 定义列表中术语的样式
+}
+
+// NewList 创建指定类型的列表，有序列表从 1 开始编号，定义列表的术语使用粗体
+func NewList(kind ListKind) *List {
+	l := &List{
+		Kind:  kind,
+		Start: 1,
+	}
+	if kind == ListDefinition {
+		l.TermStyle = New().Bold()
+	}
+	return l
+}
+
+// SetNumbering 设置有序列表的编号方式
+func (l *List) SetNumbering(numbering Numbering) *List {
+	l.Numbering = numbering
+	return l
+}
+
+// SetStart 设置有序列表的起始编号
+func (l *List) SetStart(start int) *List {
+	l.Start = start
+	return l
+}
+
+// SetBullets 设置无序列表各层的符号，子列表没有设置时沿用
+func (l *List) SetBullets(bullets ...string) *List {
+	l.Bullets = bullets
+	return l
+}
+
+// SetMarkerStyle 设置列表符号、编号和复选框的样式
+func (l *List) SetMarkerStyle(style *Style) *List {
+	l.MarkerStyle = style
+	return l
+}
+
+// SetTermStyle 设置定义列表中术语的样式
+func (l *List) SetTermStyle(style *Style) *List {
+	l.TermStyle = style
+	return l
+}
+
+// Add 添加列表项
+func (l *List) Add(text string) *ListItem {
+	item := &ListItem{Text: text, list: l}
+	l.Items = append(l.Items, item)
+	return item
+}
+
+// AddTask 添加任务列表项
+func (l *List) AddTask(text string, checked bool) *ListItem {
+	item := l.Add(text)
+	item.Checked = checked
+	return item
+}
+
+// AddDefinition 添加定义列表项
+func (l *List) AddDefinition(term, description string) *ListItem {
+	item := l.Add(term)
+	item.Description = description
+	return item
+}
+
+// AddList 为列表项创建指定类型的子列表（替换已有的子列表）
+func (i *ListItem) AddList(kind ListKind) *List {
+	i.Children = NewList(kind)
+	return i.Children
+}
+
+// Add 向子列表添加一项，没有子列表时创建一个与所属列表类型和编号方式相同的子列表
+func (i *ListItem) Add(text string) *ListItem {
+	if i.Children == nil {
+		kind, numbering := ListBullet, NumberDecimal
+		if i.list != nil {
+			kind, numbering = i.list.Kind, i.list.Numbering
+		}
+		i.AddList(kind).SetNumbering(numbering)
+	}
+	return i.Children.Add(text)
+}
+
+// String 返回列表的字符串表示（不换行）
+func (l *List) String() string {
+	return NewTextFormatter().FormatList(l, 0)
+}
+
+// Print 打印列表
+func (l *List) Print() {
+	l.Fprint(Output)
+}
+
+// Fprint 打印列表到指定的 writer（根据该 writer 决定颜色能力）
+func (l *List) Fprint(w io.Writer) {
+	lines := NewTextFormatter().formatList(rendererFor(w), l, "", listLevel{})
+	fmt.Fprintln(w, strings.Join(lines, "\n"))
+}
+
+// listLevel 记录列表所在的层级，用于选择符号和生成多级编号
+type listLevel struct {
+	depth   int      // 嵌套的层数，从 0 开始
+	bullets []string // 上级列表设置的符号
+	path    []int    // 上级有序列表的编号
+}
+
+// FormatList 格式化可以嵌套的列表。设置了宽度时按宽度换行，
+// 列表项的后续行与列表符号后的文本对齐，子列表的符号也与上级列表项的文本对齐
+func (tf *TextFormatter) FormatList(l *List, indent int) string {
+	lines := tf.formatList(DefaultRenderer(), l, strings.Repeat(" ", indent), listLevel{})
+	return strings.Join(lines, "\n")
+}
+
+// formatList 使用指定的渲染器格式化列表，indent 为列表符号之前的缩进
+func (tf *TextFormatter) formatList(r *Renderer, l *List, indent string, level listLevel) []string {
+	bullets := level.bullets
+	if len(l.Bullets) > 0 {
+		bullets = l.Bullets
+	}
+	if len(bullets) == 0 {
+		bullets = DefaultBullets
+	}
+
+	// 同一个列表中的编号右对齐，使各项的文本对齐
+	markers := make([]string, len(l.Items))
+	markerWidth := 0
+	for i, item := range l.Items {
+		markers[i] = l.marker(i, item, bullets[level.depth%len(bullets)], level.path)
+		if w := StringWidth(markers[i]); w > markerWidth {
+			markerWidth = w
+		}
+	}
+
+	var lines []string
+	for i, item := range l.Items {
+		child := listLevel{depth: level.depth + 1, bullets: bullets, path: level.path}
+		if l.Kind == ListNumbered {
+			child.path = append(append([]int(nil), level.path...), l.Start+i)
+		}
+
+		if l.Kind == ListDefinition {
+			lines = append(lines, tf.hang(r.Sprint(l.TermStyle, item.Text), indent, indent)...)
+			pad := indent + strings.Repeat(" ", definitionIndent)
+			if item.Description != "" {
+				lines = append(lines, tf.hang(item.Description, pad, pad)...)
+			}
+			if item.Children != nil {
+				lines = append(lines, tf.formatList(r, item.Children, pad, child)...)
+			}
+			continue
+		}
+
+		padding := strings.Repeat(" ", markerWidth-StringWidth(markers[i]))
+		marker := r.Sprint(l.MarkerStyle, markers[i]) + padding
+		if l.Kind == ListNumbered {
+			marker = padding + r.Sprint(l.MarkerStyle, markers[i])
+		}
+		pad := indent + strings.Repeat(" ", markerWidth+1)
+		lines = append(lines, tf.hang(item.Text, indent+marker+" ", pad)...)
+		if item.Children != nil {
+			lines = append(lines, tf.formatList(r, item.Children, pad, child)...)
+		}
+	}
+	return lines
+}
+
+// marker 返回列表项的符号、编号或复选框
+func (l *List) marker(i int, item *ListItem, bullet string, path []int) string {
+	switch l.Kind {
+	case ListNumbered:
+		return formatNumber(l.Start+i, l.Numbering, path) + "."
+	case ListTask:
+		if item.Checked {
+			return "[x]"
+		}
+		return "[ ]"
+	default:
+		return bullet
+	}
+}
+
+// formatNumber 按照编号方式格式化编号，多级编号包含上级有序列表的编号
+func formatNumber(n int, numbering Numbering, path []int) string {
+	switch numbering {
+	case NumberLowerAlpha:
+		return strings.ToLower(alphaNumber(n))
+	case NumberUpperAlpha:
+		return alphaNumber(n)
+	case NumberLowerRoman:
+		return strings.ToLower(romanNumber(n))
+	case NumberUpperRoman:
+		return romanNumber(n)
+	case NumberOutline:
+		parts := make([]string, 0, len(path)+1)
+		for _, p := range path {
+			parts = append(parts, strconv.Itoa(p))
+		}
+		return strings.Join(append(parts, strconv.Itoa(n)), ".")
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+// alphaNumber 返回字母编号：A、B、…、Z、AA、AB、…，小于 1 时使用数字
+func alphaNumber(n int) string {
+	if n < 1 {
+		return strconv.Itoa(n)
+	}
+	var letters []byte
+	for ; n > 0; n = (n - 1) / 26 {
+		letters = append([]byte{byte('A' + (n-1)%26)}, letters...)
+	}
+	return string(letters)
+}
+
+// romanNumber 返回罗马数字编号，超出 1-3999 范围时使用数字
+func romanNumber(n int) string {
+	if n < 1 || n > 3999 {
+		return strconv.Itoa(n)
+	}
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+	var out strings.Builder
+	for i, v := range values {
+		for ; n >= v; n -= v {
+			out.WriteString(symbols[i])
+		}
+	}
+	return out.String()
+}
@@ -2,18 +2,54 @@ package goterm
 
 import (
 	"fmt"
+	"io"
 	"time"
 )
 
+// Now 返回当前时间，日志的时间戳通过它获取，测试时可以替换为返回固定时间的函数
+var Now = time.Now
+
+// LogLevel 表示日志级别
+type LogLevel struct {
+	Label string              // 级别名称
+	Style *Style              // 级别样式（为空时使用主题中对应的样式）
+	role  func(*Theme) *Style // 主题中对应的样式
+}
+
+// 日志级别
+var (
+	LevelError   = &LogLevel{Label: "ERROR", role: func(t *Theme) *Style { return t.Error }}
+	LevelSuccess = &LogLevel{Label: "SUCCESS", role: func(t *Theme) *Style { return t.Success }}
+	LevelWarning = &LogLevel{Label: "WARN", role: func(t *Theme) *Style { return t.Warning }}
+	LevelInfo    = &LogLevel{Label: "INFO", role: func(t *Theme) *Style { return t.Info }}
+	LevelRemark  = &LogLevel{Label: "REMARK", role: func(t *Theme) *Style { return t.Remark }}
+)
+
+// style 返回日志级别在指定主题下使用的样式
+func (l *LogLevel) style(theme *Theme) *Style {
+	if l.Style != nil || l.role == nil {
+		return l.Style
+	}
+	return l.role(theme)
+}
+
 // 日志级别前缀
+//
+// Deprecated: 前缀在初始化时按照标准输出、默认主题和深色背景渲染，无法适配其他输出目标、主题和终端背景，
+// 请使用 LevelError 等日志级别修改名称和样式。
 var (
-	PrefixError   = New().Bold().Red().Sprint("ERROR")
-	PrefixSuccess = New().Bold().RGB(0, 128, 0).Sprint("SUCCESS")
-	PrefixWarning = New().Bold().Yellow().Sprint("WARN")
-	PrefixInfo    = New().Bold().Blue().Sprint("INFO")
-	PrefixRemark  = New().Bold().Cyan().Sprint("REMARK")
+	PrefixError   = levelPrefix(LevelError)
+	PrefixSuccess = levelPrefix(LevelSuccess)
+	PrefixWarning = levelPrefix(LevelWarning)
+	PrefixInfo    = levelPrefix(LevelInfo)
+	PrefixRemark  = levelPrefix(LevelRemark)
 )
 
+// levelPrefix 按照默认主题渲染日志级别前缀（初始化时不查询终端背景）
+func levelPrefix(level *LogLevel) string {
+	return level.style(DefaultTheme).render(DefaultRenderer().ColorProfile(), true, level.Label)
+}
+
 // 全局活跃进度条（用于日志自动适配到进度条）
 var (
 	activeProgressBar *ProgressBar
@@ -29,31 +65,12 @@ func ClearActiveProgressBar() {
 	activeProgressBar = nil
 }
 
-// 获取不带ANSI转义码的字符串长度
-func getPlainLength(s string) int {
-	length := 0
-	inEscape := false
-	for _, c := range s {
-		if c == '\x1b' {
-			inEscape = true
-			continue
-		}
-		if inEscape {
-			if c == 'm' {
-				inEscape = false
-			}
-			continue
-		}
-		length++
-	}
-	return length
-}
-
 // 格式化日志消息
-func formatLog(prefix string, message string) string {
-	now := time.Now().Format("2006-01-02 15:04:05")
-	// 计算前缀的纯文本长度
-	prefixLength := getPlainLength(prefix)
+func formatLog(r *Renderer, theme *Theme, level *LogLevel, message string) string {
+	now := Now().Format("2006-01-02 15:04:05")
+	prefix := r.Sprint(level.style(theme), level.Label)
+	// 计算前缀的显示宽度
+	prefixLength := StringWidth(prefix)
 	// 计算需要填充的空格数（SUCCESS是最长的，长度为7）
 	totalWidth := 9 // 设置总宽度为9，确保有足够的空间
 	leftPadding := (totalWidth - prefixLength) / 2
@@ -63,9 +80,9 @@ func formatLog(prefix string, message string) string {
 	return fmt.Sprintf("[%s] %s %s", now, alignedPrefix, message)
 }
 
-// 全局快捷函数 - 预设样式
-func Error(a ...any) string {
-	msg := formatLog(PrefixError, fmt.Sprint(a...))
+// logString 使用默认渲染器和全局主题格式化日志消息
+func logString(level *LogLevel, message string) string {
+	msg := formatLog(DefaultRenderer(), CurrentTheme(), level, message)
 	// 如果有活跃的进度条，则自动写入进度条
 	if activeProgressBar != nil && activeProgressBar.Type == BarTypeSticky {
 		activeProgressBar.Log("%s", msg)
@@ -73,84 +90,64 @@ func Error(a ...any) string {
 	return msg
 }
 
-func Success(a ...any) string {
-	msg := formatLog(PrefixSuccess, fmt.Sprint(a...))
-	// 如果有活跃的进度条，则自动写入进度条
-	if activeProgressBar != nil && activeProgressBar.Type == BarTypeSticky {
-		activeProgressBar.Log("%s", msg)
-	}
-	return msg
-}
-
-func Warning(a ...any) string {
-	msg := formatLog(PrefixWarning, fmt.Sprint(a...))
-	// 如果有活跃的进度条，则自动写入进度条
-	if activeProgressBar != nil && activeProgressBar.Type == BarTypeSticky {
-		activeProgressBar.Log("%s", msg)
-	}
-	return msg
-}
-
-func Info(a ...any) string {
-	msg := formatLog(PrefixInfo, fmt.Sprint(a...))
-	// 如果有活跃的进度条，则自动写入进度条
-	if activeProgressBar != nil && activeProgressBar.Type == BarTypeSticky {
-		activeProgressBar.Log("%s", msg)
-	}
-	return msg
+// logStringf 使用默认渲染器和全局主题格式化带格式的日志消息
+func logStringf(level *LogLevel, format string, a ...any) string {
+	return logString(level, fmt.Sprintf(format, a...))
 }
 
-func Remark(a ...any) string {
-	msg := formatLog(PrefixRemark, fmt.Sprint(a...))
-	// 如果有活跃的进度条，则自动写入进度条
-	if activeProgressBar != nil && activeProgressBar.Type == BarTypeSticky {
-		activeProgressBar.Log("%s", msg)
-	}
-	return msg
-}
+// 全局快捷函数 - 预设样式
+func Error(a ...any) string   { return logString(LevelError, fmt.Sprint(a...)) }
+func Success(a ...any) string { return logString(LevelSuccess, fmt.Sprint(a...)) }
+func Warning(a ...any) string { return logString(LevelWarning, fmt.Sprint(a...)) }
+func Info(a ...any) string    { return logString(LevelInfo, fmt.Sprint(a...)) }
+func Remark(a ...any) string  { return logString(LevelRemark, fmt.Sprint(a...)) }
 
 // 全局快捷函数 - 格式化输出
-func Errorf(format string, a ...any) string {
-	msg := formatLog(PrefixError, fmt.Sprintf(format, a...))
-	// 如果有活跃的进度条，则自动写入进度条
-	if activeProgressBar != nil && activeProgressBar.Type == BarTypeSticky {
-		activeProgressBar.Log("%s", msg)
-	}
-	return msg
+func Errorf(format string, a ...any) string   { return logStringf(LevelError, format, a...) }
+func Successf(format string, a ...any) string { return logStringf(LevelSuccess, format, a...) }
+func Warningf(format string, a ...any) string { return logStringf(LevelWarning, format, a...) }
+func Infof(format string, a ...any) string    { return logStringf(LevelInfo, format, a...) }
+func Remarkf(format string, a ...any) string  { return logStringf(LevelRemark, format, a...) }
+
+// Logger 将日志输出到指定的 writer，根据该 writer 决定颜色能力
+type Logger struct {
+	renderer *Renderer
+	theme    *Theme
 }
 
-func Successf(format string, a ...any) string {
-	msg := formatLog(PrefixSuccess, fmt.Sprintf(format, a...))
-	// 如果有活跃的进度条，则自动写入进度条
-	if activeProgressBar != nil && activeProgressBar.Type == BarTypeSticky {
-		activeProgressBar.Log("%s", msg)
+// NewLogger 创建一个输出到指定 writer 的日志器
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{
+		renderer: rendererFor(w),
 	}
-	return msg
 }
 
-func Warningf(format string, a ...any) string {
-	msg := formatLog(PrefixWarning, fmt.Sprintf(format, a...))
-	// 如果有活跃的进度条，则自动写入进度条
-	if activeProgressBar != nil && activeProgressBar.Type == BarTypeSticky {
-		activeProgressBar.Log("%s", msg)
-	}
-	return msg
+// SetTheme 设置日志器使用的主题（为空时使用全局主题）
+func (l *Logger) SetTheme(theme *Theme) *Logger {
+	l.theme = theme
+	return l
 }
 
-func Infof(format string, a ...any) string {
-	msg := formatLog(PrefixInfo, fmt.Sprintf(format, a...))
-	// 如果有活跃的进度条，则自动写入进度条
-	if activeProgressBar != nil && activeProgressBar.Type == BarTypeSticky {
-		activeProgressBar.Log("%s", msg)
-	}
-	return msg
+// Log 输出指定级别的日志
+func (l *Logger) Log(level *LogLevel, a ...any) {
+	l.renderer.Println(formatLog(l.renderer, themeOr(l.theme), level, fmt.Sprint(a...)))
 }
 
-func Remarkf(format string, a ...any) string {
-	msg := formatLog(PrefixRemark, fmt.Sprintf(format, a...))
-	// 如果有活跃的进度条，则自动写入进度条
-	if activeProgressBar != nil && activeProgressBar.Type == BarTypeSticky {
-		activeProgressBar.Log("%s", msg)
-	}
-	return msg
+// Logf 输出指定级别的格式化日志
+func (l *Logger) Logf(level *LogLevel, format string, a ...any) {
+	l.renderer.Println(formatLog(l.renderer, themeOr(l.theme), level, fmt.Sprintf(format, a...)))
 }
+
+// 日志器快捷方法 - 预设级别
+func (l *Logger) Error(a ...any)   { l.Log(LevelError, a...) }
+func (l *Logger) Success(a ...any) { l.Log(LevelSuccess, a...) }
+func (l *Logger) Warning(a ...any) { l.Log(LevelWarning, a...) }
+func (l *Logger) Info(a ...any)    { l.Log(LevelInfo, a...) }
+func (l *Logger) Remark(a ...any)  { l.Log(LevelRemark, a...) }
+
+// 日志器快捷方法 - 格式化输出
+func (l *Logger) Errorf(format string, a ...any)   { l.Logf(LevelError, format, a...) }
+func (l *Logger) Successf(format string, a ...any) { l.Logf(LevelSuccess, format, a...) }
+func (l *Logger) Warningf(format string, a ...any) { l.Logf(LevelWarning, format, a...) }
+func (l *Logger) Infof(format string, a ...any)    { l.Logf(LevelInfo, format, a...) }
+func (l *Logger) Remarkf(format string, a ...any)  { l.Logf(LevelRemark, format, a...) }
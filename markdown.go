@@ -0,0 +1,967 @@
+package goterm
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MarkdownRenderer 将 Markdown 文本渲染为带有样式的终端文本。
+// 支持标题、强调、行内代码、代码块、引用、嵌套列表、表格、链接和分隔线，
+// 段落按照宽度换行，各元素的样式从主题中获取
+type MarkdownRenderer struct {
+	Width int    // 换行宽度，为 0 时使用终端宽度
+	Theme *Theme // 主题（为空时使用全局主题）
+}
+
+// NewMarkdownRenderer 创建按照终端宽度换行、使用全局主题的 Markdown 渲染器
+func NewMarkdownRenderer() *MarkdownRenderer {
+	return &MarkdownRenderer{}
+}
+
+// Markdown 使用默认设置渲染 Markdown 文本
+func Markdown(text string) string {
+	return NewMarkdownRenderer().Render(text)
+}
+
+// SetWidth 设置换行宽度（按显示宽度计算），为 0 时使用终端宽度
+func (m *MarkdownRenderer) SetWidth(width int) *MarkdownRenderer {
+	m.Width = width
+	return m
+}
+
+// SetTheme 设置渲染使用的主题
+func (m *MarkdownRenderer) SetTheme(theme *Theme) *MarkdownRenderer {
+	m.Theme = theme
+	return m
+}
+
+// Render 渲染 Markdown 文本（使用默认渲染器的颜色能力和终端宽度）
+func (m *MarkdownRenderer) Render(text string) string {
+	return m.render(DefaultRenderer(), text)
+}
+
+// Print 渲染并打印 Markdown 文本
+func (m *MarkdownRenderer) Print(text string) {
+	m.Fprint(Output, text)
+}
+
+// Fprint 渲染 Markdown 文本并打印到指定的 writer（根据该 writer 决定颜色能力、超链接和宽度）
+func (m *MarkdownRenderer) Fprint(w io.Writer, text string) {
+	fmt.Fprintln(w, m.render(rendererFor(w), text))
+}
+
+// render 使用指定的渲染器渲染 Markdown 文本
+func (m *MarkdownRenderer) render(r *Renderer, text string) string {
+	width := m.Width
+	if width <= 0 {
+		width = NewTerminal(r).Width()
+	}
+
+	p := &markdownParser{refs: map[string]string{}}
+	blocks := p.blocks(markdownLines(text))
+	c := &markdownContext{r: r, theme: themeOr(m.Theme), refs: p.refs}
+	return strings.Join(c.blocks(blocks, width, true), "\n")
+}
+
+// markdownKind 表示块级元素的类型
+type markdownKind int
+
+const (
+	markdownParagraph markdownKind = iota // 段落
+	markdownHeading                       // 标题
+	markdownCode                          // 代码块
+	markdownQuote                         // 引用
+	markdownList                          // 列表
+	markdownTable                         // 表格
+	markdownRule                          // 分隔线
+)
+
+// markdownBlock 表示一个块级元素
+type markdownBlock struct {
+	kind     markdownKind
+	text     string           // 段落和标题的行内文本
+	level    int              // 标题的级别
+	lines    []string         // 代码块的各行
+	info     string           // 代码块的语言
+	children []*markdownBlock // 引用中的块
+	items    []*markdownItem  // 列表项
+	ordered  bool             // 是否为有序列表
+	start    int              // 有序列表的起始编号
+	loose    bool             // 列表项之间是否有空行
+	header   []string         // 表头
+	aligns   []Alignment      // 表格各列的对齐方式
+	rows     [][]string       // 表格的数据行
+}
+
+// markdownItem 表示列表中的一项
+type markdownItem struct {
+	blocks  []*markdownBlock // 列表项包含的块
+	task    bool             // 是否为任务列表项
+	checked bool             // 任务是否已经完成
+	loose   bool             // 列表项中的块之间是否有空行
+}
+
+var (
+	markdownHeadingRe = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	markdownRuleRe    = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	markdownFenceRe   = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	markdownListRe    = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])([ \t]+|$)`)
+	markdownSetextRe  = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	markdownQuoteRe   = regexp.MustCompile(`^ {0,3}> ?`)
+	markdownTaskRe    = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+|$)`)
+	markdownRefRe     = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:[ \t]*<?([^\s>]+)>?(?:[ \t]+.*)?$`)
+	markdownDelimRe   = regexp.MustCompile(`^:?-+:?$`)
+)
+
+// markdownLines 将文本拆分为行，统一换行符并把制表符展开为空格（制表位间隔 4 列）
+func markdownLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, "\t") {
+			continue
+		}
+		var out strings.Builder
+		column := 0
+		for _, ch := range line {
+			if ch == '\t' {
+				n := 4 - column%4
+				out.WriteString(strings.Repeat(" ", n))
+				column += n
+				continue
+			}
+			out.WriteRune(ch)
+			column++
+		}
+		lines[i] = out.String()
+	}
+	return lines
+}
+
+// markdownParser 解析块级元素，同时收集链接引用定义
+type markdownParser struct {
+	refs map[string]string // 链接引用定义，键为规范化的标签
+}
+
+// blocks 解析各行中的块级元素
+func (p *markdownParser) blocks(lines []string) []*markdownBlock {
+	var blocks []*markdownBlock
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		var block *markdownBlock
+		n := 1
+		switch {
+		case isBlankLine(line):
+			i++
+			continue
+		case markdownFenceRe.MatchString(line):
+			block, n = parseFence(lines[i:])
+		case markdownHeadingRe.MatchString(line):
+			m := markdownHeadingRe.FindStringSubmatch(line)
+			block = &markdownBlock{kind: markdownHeading, level: len(m[1]), text: m[2]}
+		case markdownRuleRe.MatchString(line):
+			block = &markdownBlock{kind: markdownRule}
+		case markdownQuoteRe.MatchString(line):
+			block, n = p.quote(lines[i:])
+		case markdownListRe.MatchString(line):
+			block, n = p.list(lines[i:])
+		case indentOf(line) >= 4:
+			block, n = parseIndentedCode(lines[i:])
+		case i+1 < len(lines) && isTableRow(line) && isTableDelimiter(lines[i+1]):
+			block, n = parseTable(lines[i:])
+		case markdownRefRe.MatchString(line):
+			m := markdownRefRe.FindStringSubmatch(line)
+			if label := markdownLabel(m[1]); p.refs[label] == "" {
+				p.refs[label] = m[2]
+			}
+			i++
+			continue
+		default:
+			block, n = parseParagraph(lines[i:])
+		}
+		blocks = append(blocks, block)
+		i += n
+	}
+	return blocks
+}
+
+// parseFence 解析用 ``` 或 ~~~ 包围的代码块，返回代码块和占用的行数
+func parseFence(lines []string) (*markdownBlock, int) {
+	m := markdownFenceRe.FindStringSubmatch(lines[0])
+	indent, fence := len(m[1]), m[2]
+	block := &markdownBlock{kind: markdownCode}
+	if fields := strings.Fields(m[3]); len(fields) > 0 {
+		block.info = fields[0]
+	}
+
+	i := 1
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if trimmed := strings.TrimSpace(line); indentOf(line) < 4 &&
+			strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			return block, i + 1
+		}
+		// 去掉与开始标记相同的缩进
+		if n := indentOf(line); n < indent {
+			line = line[n:]
+		} else {
+			line = line[indent:]
+		}
+		block.lines = append(block.lines, line)
+	}
+	return block, i
+}
+
+// parseIndentedCode 解析缩进 4 个空格的代码块
+func parseIndentedCode(lines []string) (*markdownBlock, int) {
+	block := &markdownBlock{kind: markdownCode}
+	i := 0
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isBlankLine(line) {
+			block.lines = append(block.lines, "")
+			continue
+		}
+		if indentOf(line) < 4 {
+			break
+		}
+		block.lines = append(block.lines, line[4:])
+	}
+	for len(block.lines) > 0 && block.lines[len(block.lines)-1] == "" {
+		block.lines = block.lines[:len(block.lines)-1]
+	}
+	return block, i
+}
+
+// parseParagraph 解析段落，段落后面是 === 或 --- 时解析为标题。
+// 以两个空格或反斜杠结尾的行是强制换行，其余的换行符视为空格（两个宽字符之间的换行符忽略）
+func parseParagraph(lines []string) (*markdownBlock, int) {
+	block := &markdownBlock{kind: markdownParagraph}
+	var text strings.Builder
+	separator := ""
+	i := 0
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if i > 0 {
+			if isBlankLine(line) {
+				break
+			}
+			if m := markdownSetextRe.FindStringSubmatch(line); m != nil {
+				block.kind, block.level = markdownHeading, 2
+				if m[1][0] == '=' {
+					block.level = 1
+				}
+				i++
+				break
+			}
+			if interruptsParagraph(line) {
+				break
+			}
+		}
+
+		line = strings.TrimLeft(line, " ")
+		if separator == " " {
+			last, _ := utf8.DecodeLastRuneInString(text.String())
+			next, _ := utf8.DecodeRuneInString(line)
+			if RuneWidth(last) == 2 && RuneWidth(next) == 2 {
+				separator = ""
+			}
+		}
+		text.WriteString(separator)
+
+		switch {
+		case strings.HasSuffix(line, "\\"):
+			text.WriteString(strings.TrimSuffix(line, "\\"))
+			separator = "\n"
+		case strings.HasSuffix(line, "  "):
+			text.WriteString(strings.TrimRight(line, " "))
+			separator = "\n"
+		default:
+			text.WriteString(strings.TrimRight(line, " "))
+			separator = " "
+		}
+	}
+	block.text = text.String()
+	return block, i
+}
+
+// quote 解析引用，引用中的段落可以省略后续行开头的 >
+func (p *markdownParser) quote(lines []string) (*markdownBlock, int) {
+	var inner []string
+	i := 0
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if loc := markdownQuoteRe.FindStringIndex(line); loc != nil {
+			inner = append(inner, line[loc[1]:])
+			continue
+		}
+		last := len(inner) - 1
+		if isBlankLine(line) || isBlankLine(inner[last]) || interruptsParagraph(line) {
+			break
+		}
+		inner = append(inner, line)
+	}
+	return &markdownBlock{kind: markdownQuote, children: p.blocks(inner)}, i
+}
+
+// list 解析列表，列表项中缩进到文本位置的行属于该列表项（包括嵌套的列表）
+func (p *markdownParser) list(lines []string) (*markdownBlock, int) {
+	first := markdownListRe.FindStringSubmatch(lines[0])
+	block := &markdownBlock{kind: markdownList, ordered: isDigit(first[2][0])}
+	if block.ordered {
+		block.start, _ = strconv.Atoi(first[2][:len(first[2])-1])
+	}
+	// 同一个列表使用相同的符号（或编号后的分隔符）
+	delimiter := first[2][len(first[2])-1]
+	sibling := func(line string) []string {
+		m := markdownListRe.FindStringSubmatch(line)
+		if m == nil || isDigit(m[2][0]) != block.ordered || m[2][len(m[2])-1] != delimiter ||
+			markdownRuleRe.MatchString(line) {
+			return nil
+		}
+		return m
+	}
+
+	i := 0
+	for i < len(lines) {
+		m := sibling(lines[i])
+		if m == nil {
+			break
+		}
+
+		// 列表项的文本从符号后的第一个非空白字符开始，空白超过 4 个时视为 1 个
+		content := len(m[1]) + len(m[2]) + 1
+		if spaces := len(m[3]); spaces > 0 && spaces <= 4 && len(m[0]) < len(lines[i]) {
+			content = len(m[0])
+		}
+		text := ""
+		if content < len(lines[i]) {
+			text = lines[i][content:]
+		}
+		itemLines := []string{text}
+		i++
+
+	collect:
+		for i < len(lines) {
+			line := lines[i]
+			switch {
+			case isBlankLine(line):
+				itemLines = append(itemLines, "")
+			case indentOf(line) >= content:
+				itemLines = append(itemLines, line[content:])
+			case itemLines[len(itemLines)-1] != "" && !markdownListRe.MatchString(line) && !interruptsParagraph(line):
+				// 段落的延续行可以不缩进
+				itemLines = append(itemLines, strings.TrimLeft(line, " "))
+			default:
+				break collect
+			}
+			i++
+		}
+
+		// 列表项末尾的空行属于列表项之间，后面还有列表项时列表是松散的
+		trailing := 0
+		for len(itemLines) > 1 && itemLines[len(itemLines)-1] == "" {
+			itemLines = itemLines[:len(itemLines)-1]
+			trailing++
+		}
+		if trailing > 0 && i < len(lines) && sibling(lines[i]) != nil {
+			block.loose = true
+		}
+
+		item := &markdownItem{}
+		if t := markdownTaskRe.FindStringSubmatch(itemLines[0]); t != nil {
+			item.task, item.checked = true, t[1] != " "
+			itemLines[0] = itemLines[0][len(t[0]):]
+		}
+		for j := 1; j < len(itemLines)-1; j++ {
+			if itemLines[j] == "" && itemLines[j-1] != "" {
+				item.loose = true
+			}
+		}
+		item.blocks = p.blocks(itemLines)
+		block.items = append(block.items, item)
+	}
+	return block, i
+}
+
+// parseTable 解析 GFM 表格：表头、分隔行（指定对齐方式）和数据行
+func parseTable(lines []string) (*markdownBlock, int) {
+	block := &markdownBlock{kind: markdownTable, header: splitTableRow(lines[0])}
+	for _, cell := range splitTableRow(lines[1]) {
+		align := AlignLeft
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			align = AlignCenter
+		case strings.HasSuffix(cell, ":"):
+			align = AlignRight
+		}
+		block.aligns = append(block.aligns, align)
+	}
+	for len(block.aligns) < len(block.header) {
+		block.aligns = append(block.aligns, AlignLeft)
+	}
+
+	i := 2
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isBlankLine(line) || interruptsParagraph(line) {
+			break
+		}
+		row := splitTableRow(line)
+		for len(row) < len(block.header) {
+			row = append(row, "")
+		}
+		block.rows = append(block.rows, row[:len(block.header)])
+	}
+	return block, i
+}
+
+// splitTableRow 拆分表格行中的单元格，\| 表示单元格中的竖线
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// isTableRow 判断一行是否可能是表格行
+func isTableRow(line string) bool {
+	return strings.Contains(line, "|")
+}
+
+// isTableDelimiter 判断一行是否是表格的分隔行，例如 |---|:---:|
+func isTableDelimiter(line string) bool {
+	if !strings.Contains(line, "|") {
+		return false
+	}
+	for _, cell := range splitTableRow(line) {
+		if !markdownDelimRe.MatchString(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+// interruptsParagraph 判断一行是否开始新的块（因此结束前面的段落）
+func interruptsParagraph(line string) bool {
+	if markdownFenceRe.MatchString(line) || markdownHeadingRe.MatchString(line) ||
+		markdownRuleRe.MatchString(line) || markdownQuoteRe.MatchString(line) {
+		return true
+	}
+	// 只有非空的无序列表项和从 1 开始的有序列表项可以结束段落
+	if m := markdownListRe.FindStringSubmatch(line); m != nil {
+		return strings.TrimSpace(line[len(m[0]):]) != "" &&
+			(!isDigit(m[2][0]) || m[2][:len(m[2])-1] == "1")
+	}
+	return false
+}
+
+// isBlankLine 判断一行是否只包含空白
+func isBlankLine(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// indentOf 返回行首空格的数量
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// isDigit 判断字节是否是十进制数字
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// markdownLabel 规范化链接引用的标签：忽略大小写，合并空白
+func markdownLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+// markdownContext 保存渲染过程中的设置和状态
+type markdownContext struct {
+	r     *Renderer
+	theme *Theme
+	refs  map[string]string // 链接引用定义
+	depth int               // 当前无序列表的嵌套层数，用于选择列表符号
+}
+
+// blocks 渲染一组块，loose 为 true 时块之间空一行
+func (c *markdownContext) blocks(blocks []*markdownBlock, width int, loose bool) []string {
+	var lines []string
+	for i, block := range blocks {
+		if i > 0 && loose {
+			lines = append(lines, "")
+		}
+		lines = append(lines, c.block(block, width)...)
+	}
+	return lines
+}
+
+// block 渲染一个块，返回输出的各行
+func (c *markdownContext) block(b *markdownBlock, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	switch b.kind {
+	case markdownHeading:
+		return wrapText(c.inline(b.text, c.headingStyle(b.level)), wrapOptions{width: width})
+	case markdownCode:
+		return c.code(b, width)
+	case markdownQuote:
+		return c.quote(b, width)
+	case markdownList:
+		return c.list(b, width)
+	case markdownTable:
+		return c.table(b, width)
+	case markdownRule:
+		return []string{c.r.Sprint(c.theme.Border, strings.Repeat("─", width))}
+	default:
+		return wrapText(c.inline(b.text, nil), wrapOptions{width: width})
+	}
+}
+
+// headingStyle 返回各级标题的样式：一级标题使用强调色的标题样式，二级标题使用标题样式，其余使用数值样式（粗体）
+func (c *markdownContext) headingStyle(level int) *Style {
+	switch level {
+	case 1:
+		return c.theme.Title.Inherit(c.theme.Accent)
+	case 2:
+		return c.theme.Title
+	default:
+		return c.theme.Value
+	}
+}
+
+// code 将代码块绘制在圆角方框中，语言显示为方框的标题。
+// 超出宽度的行按显示宽度直接折断，保留代码中的缩进和连续空格
+func (c *markdownContext) code(b *markdownBlock, width int) []string {
+	// 方框两侧各有一个边框字符和一个空格
+	inner := width - 4
+	if inner < 2 {
+		inner = 2
+	}
+	var lines []string
+	for _, line := range b.lines {
+		lines = append(lines, breakLine(line, inner)...)
+	}
+
+	box := NewBox(strings.Join(lines, "\n")).
+		SetBorder(BorderRounded).
+		SetBorderStyle(c.theme.Muted).
+		SetTitle(b.info).
+		SetTitleStyle(c.theme.Muted).
+		SetTheme(c.theme)
+	if StringWidth(b.info)+4 > width {
+		box.SetWidth(inner + 4)
+	}
+	return strings.Split(box.render(c.r), "\n")
+}
+
+// breakLine 将一行按显示宽度折断为多行，不合并空白，字素簇不会被拆开
+func breakLine(line string, width int) []string {
+	var lines []string
+	start, lineWidth := 0, 0
+	for i := 0; i < len(line); {
+		size, w := nextGrapheme(line[i:])
+		if lineWidth+w > width && i > start {
+			lines = append(lines, line[start:i])
+			start, lineWidth = i, 0
+		}
+		lineWidth += w
+		i += size
+	}
+	return append(lines, line[start:])
+}
+
+// quote 渲染引用，每一行前面添加弱化的竖线
+func (c *markdownContext) quote(b *markdownBlock, width int) []string {
+	bar := c.r.Sprint(c.theme.Muted, "│")
+	lines := c.blocks(b.children, width-2, true)
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = bar
+		} else {
+			lines[i] = bar + " " + line
+		}
+	}
+	return lines
+}
+
+// list 渲染列表：有序列表的编号右对齐，任务列表项的符号是复选框，
+// 列表项的内容（包括嵌套的块）与符号后的文本对齐
+func (c *markdownContext) list(b *markdownBlock, width int) []string {
+	bullet := DefaultBullets[c.depth%len(DefaultBullets)]
+	numberWidth := 0
+	if b.ordered {
+		numberWidth = StringWidth(formatNumber(b.start+len(b.items)-1, NumberDecimal, nil) + ".")
+	}
+
+	if !b.ordered {
+		c.depth++
+		defer func() { c.depth-- }()
+	}
+
+	var lines []string
+	for i, item := range b.items {
+		if i > 0 && b.loose {
+			lines = append(lines, "")
+		}
+
+		var marker string
+		switch {
+		case b.ordered:
+			number := formatNumber(b.start+i, NumberDecimal, nil) + "."
+			marker = strings.Repeat(" ", numberWidth-StringWidth(number)) + c.r.Sprint(c.theme.Accent, number)
+			if item.task {
+				marker += " " + c.r.Sprint(c.theme.Accent, checkbox(item.checked))
+			}
+		case item.task:
+			marker = c.r.Sprint(c.theme.Accent, checkbox(item.checked))
+		default:
+			marker = c.r.Sprint(c.theme.Accent, bullet)
+		}
+		indent := StringWidth(marker) + 1
+
+		content := c.blocks(item.blocks, width-indent, item.loose)
+		if len(content) == 0 {
+			content = []string{""}
+		}
+		lines = append(lines, strings.TrimRight(marker+" "+content[0], " "))
+		pad := strings.Repeat(" ", indent)
+		for _, line := range content[1:] {
+			if line != "" {
+				line = pad + line
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// checkbox 返回任务列表项的复选框
+func checkbox(checked bool) string {
+	if checked {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+// table 使用 Table 渲染表格，表格超出宽度时缩小最宽的列（截断的内容以省略号结尾）
+func (c *markdownContext) table(b *markdownBlock, width int) []string {
+	t := NewEmptyTable().SetTheme(c.theme)
+	for i, header := range b.header {
+		t.AddColumn(NewColumn(c.inline(header, nil)).SetAlignment(b.aligns[i]))
+	}
+	for _, row := range b.rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = c.inline(cell, nil)
+		}
+		t.AddRow(cells...)
+	}
+
+	return strings.Split(strings.TrimSuffix(t.styledString(c.r, width), "\n"), "\n")
+}
+
+// markdownText 保存渲染后的行内文本和对应的纯文本
+type markdownText struct {
+	styled strings.Builder
+	plain  strings.Builder
+}
+
+// write 使用样式输出一段文本
+func (t *markdownText) write(r *Renderer, style *Style, text string) {
+	if text == "" {
+		return
+	}
+	t.styled.WriteString(r.Sprint(style, text))
+	t.plain.WriteString(text)
+}
+
+// inline 渲染行内元素，base 为所在块的样式
+func (c *markdownContext) inline(text string, base *Style) string {
+	var out markdownText
+	c.spans(&out, text, base)
+	return out.styled.String()
+}
+
+// spans 解析并渲染行内元素。嵌套的强调继承外层的样式，渲染为一段段独立的样式文本
+func (c *markdownContext) spans(out *markdownText, text string, style *Style) {
+	var buf strings.Builder
+	flush := func() {
+		out.write(c.r, style, buf.String())
+		buf.Reset()
+	}
+
+	for i := 0; i < len(text); {
+		ch := text[i]
+		switch {
+		case ch == '\\' && i+1 < len(text) && isPunctuation(text[i+1]):
+			buf.WriteByte(text[i+1])
+			i += 2
+			continue
+
+		case ch == '`':
+			n := countRun(text[i:], '`')
+			if end := findCodeEnd(text, i+n, n); end >= 0 {
+				flush()
+				code := strings.ReplaceAll(text[i+n:end], "\n", " ")
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				out.write(c.r, c.theme.Accent.Inherit(style), code)
+				i = end + n
+				continue
+			}
+			buf.WriteString(text[i : i+n])
+			i += n
+			continue
+
+		case ch == '*' || ch == '_' || ch == '~':
+			if n, end, emphasis := findEmphasis(text, i); end >= 0 {
+				flush()
+				c.spans(out, text[i+n:end], emphasis.Inherit(style))
+				i = end + n
+				continue
+			}
+			n := countRun(text[i:], ch)
+			buf.WriteString(text[i : i+n])
+			i += n
+			continue
+
+		case ch == '!' && i+1 < len(text) && text[i+1] == '[':
+			// 图片显示为指向图片地址的链接，链接文本为替代文本
+			if label, url, end, ok := c.parseLink(text, i+1); ok {
+				flush()
+				if label == "" {
+					label = url
+				}
+				c.link(out, url, style, func(t *markdownText, s *Style) { c.spans(t, label, s) })
+				i = end
+				continue
+			}
+
+		case ch == '[':
+			if label, url, end, ok := c.parseLink(text, i); ok {
+				flush()
+				c.link(out, url, style, func(t *markdownText, s *Style) { c.spans(t, label, s) })
+				i = end
+				continue
+			}
+
+		case ch == '<':
+			// 自动链接 <https://example.com> 和 <user@example.com>
+			if end := strings.IndexByte(text[i:], '>'); end > 1 {
+				address := text[i+1 : i+end]
+				url := address
+				if !strings.Contains(address, "://") && strings.Contains(address, "@") {
+					url = "mailto:" + address
+				}
+				if !strings.ContainsAny(address, " <") && (strings.Contains(address, "://") || strings.Contains(address, "@")) {
+					flush()
+					c.link(out, url, style, func(t *markdownText, s *Style) { t.write(c.r, s, address) })
+					i += end + 1
+					continue
+				}
+			}
+
+		case ch == 'h' && (strings.HasPrefix(text[i:], "https://") || strings.HasPrefix(text[i:], "http://")) &&
+			(i == 0 || !isWordByte(text[i-1])):
+			// 文本中的网址，末尾的标点不属于网址
+			end := i
+			for end < len(text) && text[end] != ' ' && text[end] != '\n' && text[end] != '<' {
+				end++
+			}
+			url := strings.TrimRight(text[i:end], ".,:;!?'\")")
+			flush()
+			c.link(out, url, style, func(t *markdownText, s *Style) { t.write(c.r, s, url) })
+			i += len(url)
+			continue
+		}
+
+		buf.WriteByte(ch)
+		i++
+	}
+	flush()
+}
+
+// link 渲染链接：支持时使用 OSC 8 超链接，否则在链接文本后面显示地址
+func (c *markdownContext) link(out *markdownText, url string, style *Style, label func(*markdownText, *Style)) {
+	var text markdownText
+	label(&text, New().Underline().Inherit(c.theme.Info).Inherit(style))
+	plain := text.plain.String()
+	// 自动链接的文本就是地址，不需要再显示一次
+	if strings.TrimPrefix(url, "mailto:") == plain {
+		plain = url
+	}
+	out.styled.WriteString(c.r.link(text.styled.String(), plain, url))
+	out.plain.WriteString(text.plain.String())
+}
+
+// parseLink 解析从 i 开始的 [文本](地址)、[文本][标签]、[文本][] 或 [标签]，
+// 返回链接文本、地址和链接之后的位置
+func (c *markdownContext) parseLink(text string, i int) (label, url string, end int, ok bool) {
+	closing := matchBracket(text, i, '[', ']')
+	if closing < 0 {
+		return "", "", 0, false
+	}
+	label = text[i+1 : closing]
+	end = closing + 1
+
+	if end < len(text) && text[end] == '(' {
+		if paren := matchBracket(text, end, '(', ')'); paren >= 0 {
+			destination := strings.TrimSpace(text[end+1 : paren])
+			if strings.HasPrefix(destination, "<") {
+				if gt := strings.IndexByte(destination, '>'); gt > 0 {
+					destination = destination[1:gt]
+				}
+			} else if fields := strings.Fields(destination); len(fields) > 0 {
+				// 地址后面的部分是链接的标题，终端中不显示
+				destination = fields[0]
+			}
+			return label, destination, paren + 1, true
+		}
+	}
+
+	ref := label
+	if end < len(text) && text[end] == '[' {
+		if refEnd := matchBracket(text, end, '[', ']'); refEnd >= 0 {
+			if r := text[end+1 : refEnd]; r != "" {
+				ref = r
+			}
+			end = refEnd + 1
+		}
+	}
+	if url, found := c.refs[markdownLabel(ref)]; found {
+		return label, url, end, true
+	}
+	return "", "", 0, false
+}
+
+// matchBracket 返回与 text[i] 处的开括号配对的闭括号的位置，忽略转义的括号，找不到时返回 -1
+func matchBracket(text string, i int, open, close byte) int {
+	depth := 0
+	for j := i; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// findEmphasis 查找从 i 开始的强调标记对应的结束标记，返回标记的长度、结束标记的位置和强调的样式。
+// * 和 _ 表示斜体，** 和 __ 表示粗体，*** 表示粗斜体，~~ 表示删除线；没有结束标记时位置为 -1
+func findEmphasis(text string, i int) (n, end int, style *Style) {
+	ch := text[i]
+	run := countRun(text[i:], ch)
+	switch {
+	case ch == '~':
+		if run != 2 {
+			return 0, -1, nil
+		}
+		n, style = 2, New().Strikethrough()
+	case run >= 3:
+		n, style = 3, New().Bold().Italic()
+	case run == 2:
+		n, style = 2, New().Bold()
+	default:
+		n, style = 1, New().Italic()
+	}
+
+	// 开始标记后面不能是空白，单词中间的 _ 不表示强调
+	if i+n >= len(text) || isSpaceByte(text[i+n]) || (ch == '_' && i > 0 && isWordByte(text[i-1])) {
+		return 0, -1, nil
+	}
+
+	for j := i + n; j < len(text); {
+		if text[j] == '`' {
+			// 代码中的标记不作为结束标记
+			k := countRun(text[j:], '`')
+			if codeEnd := findCodeEnd(text, j+k, k); codeEnd >= 0 {
+				j = codeEnd + k
+				continue
+			}
+			j += k
+			continue
+		}
+		if text[j] != ch {
+			j++
+			continue
+		}
+		k := countRun(text[j:], ch)
+		if k == n && j > i+n && !isSpaceByte(text[j-1]) &&
+			(ch != '_' || j+k >= len(text) || !isWordByte(text[j+k])) {
+			return n, j, style
+		}
+		j += k
+	}
+	return 0, -1, nil
+}
+
+// findCodeEnd 查找与长度为 n 的反引号开始标记配对的结束标记，找不到时返回 -1
+func findCodeEnd(text string, from, n int) int {
+	for j := from; j < len(text); {
+		if text[j] != '`' {
+			j++
+			continue
+		}
+		k := countRun(text[j:], '`')
+		if k == n {
+			return j
+		}
+		j += k
+	}
+	return -1
+}
+
+// countRun 返回 text 开头连续的 ch 的数量
+func countRun(text string, ch byte) int {
+	n := 0
+	for n < len(text) && text[n] == ch {
+		n++
+	}
+	return n
+}
+
+// isPunctuation 判断字节是否是可以用反斜杠转义的 ASCII 标点
+func isPunctuation(b byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", b) >= 0
+}
+
+// isSpaceByte 判断字节是否是空白
+func isSpaceByte(b byte) bool {
+	return b == ' ' || b == '\n' || b == '\t'
+}
+
+// isWordByte 判断字节是否是单词的一部分（字母、数字或多字节字符）
+func isWordByte(b byte) bool {
+	return b >= 0x80 || isDigit(b) || (b|0x20 >= 'a' && b|0x20 <= 'z')
+}
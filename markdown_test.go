@@ -0,0 +1,24 @@
+package goterm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownCodeBlockKeepsWhitespace(t *testing.T) {
+	r := NewRenderer(&bytes.Buffer{})
+	source := "```\nfunc f() {\n                fmt.Println(\"a very long line that   keeps going\")\n}\n```"
+	got := NewMarkdownRenderer().SetWidth(40).render(r, source)
+	want := strings.Join([]string{
+		"╭──────────────────────────────────────╮",
+		"│ func f() {                           │",
+		"│                 fmt.Println(\"a very  │",
+		"│ long line that   keeps going\")       │",
+		"│ }                                    │",
+		"╰──────────────────────────────────────╯",
+	}, "\n")
+	if got != want {
+		t.Errorf("code block:\n%s\nwant:\n%s", got, want)
+	}
+}
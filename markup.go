@@ -0,0 +1,238 @@
+package goterm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Markup 解析样式标记并返回带有样式的字符串（使用默认渲染器的颜色能力）。
+//
+// 标记语法：
+//   - [bold red]文本[/]：使用空格分隔的样式描述开启一个样式，[/] 关闭最近开启的样式
+//   - [/bold red]：关闭最近开启的同名样式
+//   - 样式可以嵌套，内层样式会继承外层样式
+//   - 颜色的格式参见 ParseColor，on 之后的颜色为背景色
+//   - [link=https://example.com]：为文本添加超链接
+//   - [[ 或 \[ 表示字面量 [；无法解析的标记按原样输出
+//
+// 参数 a 不为空时，format 会在解析标记之后使用 fmt.Sprintf 格式化，
+// 因此参数中的方括号不会被当作标记解析
+func Markup(format string, a ...any) string {
+	return DefaultRenderer().Markup(format, a...)
+}
+
+// Markup 使用渲染器的颜色能力解析样式标记并返回带有样式的字符串，语法参见 Markup
+func (r *Renderer) Markup(format string, a ...any) string {
+	if len(a) > 0 {
+		return fmt.Sprintf(renderMarkup(r, format, true), a...)
+	}
+	return renderMarkup(r, format, false)
+}
+
+// markupTag 表示一个已开启的样式标记
+type markupTag struct {
+	spec  string // 样式描述
+	style *Style // 与外层样式合并后的样式
+}
+
+// renderMarkup 解析样式标记并使用渲染器渲染。
+// format 为 true 时结果还会作为 fmt.Sprintf 的格式字符串，标记产生的超链接地址中的 % 会被转义
+func renderMarkup(r *Renderer, text string, format bool) string {
+	var (
+		result  strings.Builder
+		segment strings.Builder
+		stack   []markupTag
+	)
+
+	// 使用当前样式输出已累积的文本
+	flush := func() {
+		if segment.Len() == 0 {
+			return
+		}
+		if len(stack) == 0 {
+			result.WriteString(segment.String())
+		} else {
+			style := stack[len(stack)-1].style
+			if url := style.linkURL(); format && strings.Contains(url, "%") {
+				style = style.Link(strings.ReplaceAll(url, "%", "%%"))
+			}
+			result.WriteString(r.Sprint(style, segment.String()))
+		}
+		segment.Reset()
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		// 转义的方括号
+		if c == '\\' && i+1 < len(text) && text[i+1] == '[' {
+			segment.WriteByte('[')
+			i++
+			continue
+		}
+		if c != '[' {
+			segment.WriteByte(c)
+			continue
+		}
+		if i+1 < len(text) && text[i+1] == '[' {
+			segment.WriteByte('[')
+			i++
+			continue
+		}
+
+		end := strings.IndexByte(text[i+1:], ']')
+		if end < 0 {
+			segment.WriteByte(c)
+			continue
+		}
+		spec := strings.TrimSpace(text[i+1 : i+1+end])
+
+		// 关闭标记
+		if strings.HasPrefix(spec, "/") {
+			index := closingTag(stack, strings.TrimSpace(spec[1:]))
+			if index < 0 {
+				segment.WriteByte(c)
+				continue
+			}
+			flush()
+			stack = stack[:index]
+			i += end + 1
+			continue
+		}
+
+		// 开启标记，无法解析时按原样输出
+		style, err := ParseStyle(spec)
+		if err != nil || spec == "" {
+			segment.WriteByte(c)
+			continue
+		}
+		flush()
+		if len(stack) > 0 {
+			style = style.Inherit(stack[len(stack)-1].style)
+		}
+		stack = append(stack, markupTag{spec: spec, style: style})
+		i += end + 1
+	}
+	flush()
+
+	return result.String()
+}
+
+// closingTag 返回关闭标记对应的样式在栈中的位置，找不到时返回 -1
+func closingTag(stack []markupTag, spec string) int {
+	if len(stack) == 0 {
+		return -1
+	}
+	if spec == "" {
+		return len(stack) - 1
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].spec == spec {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseStyle 解析空格分隔的样式描述，例如 "bold red on white"、"underline #ff8800"
+func ParseStyle(spec string) (*Style, error) {
+	style := New()
+	background := false
+
+	for _, token := range splitStyleSpec(spec) {
+		word := strings.ToLower(token)
+
+		if word == "on" {
+			background = true
+			continue
+		}
+
+		if strings.HasPrefix(word, "link=") {
+			style = style.Link(token[len("link="):])
+			continue
+		}
+
+		if !background {
+			if attr, ok := styleAttributes[word]; ok {
+				style = style.add(attr)
+				continue
+			}
+		}
+
+		code, err := parseColorCode(word, background)
+		if err != nil {
+			return nil, err
+		}
+		style = style.add(code)
+		background = false
+	}
+
+	if background {
+		return nil, errors.New("goterm: missing background color after \"on\"")
+	}
+	return style, nil
+}
+
+// splitStyleSpec 按空格拆分样式描述，括号内的空格不拆分
+func splitStyleSpec(spec string) []string {
+	var (
+		tokens []string
+		token  strings.Builder
+		depth  int
+	)
+	for _, c := range spec {
+		switch {
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == ' ' && depth == 0:
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+			continue
+		case c == ' ':
+			continue
+		}
+		token.WriteRune(c)
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens
+}
+
+// styleAttributes 样式标记中支持的文本属性
+var styleAttributes = map[string]string{
+	"bold":             Bold,
+	"b":                Bold,
+	"faint":            Faint,
+	"dim":              Faint,
+	"italic":           Italic,
+	"i":                Italic,
+	"underline":        Underline,
+	"u":                Underline,
+	"blink":            Blink,
+	"reverse":          Reverse,
+	"strike":           Strikethrough,
+	"strikethrough":    Strikethrough,
+	"s":                Strikethrough,
+	"overline":         Overline,
+	"double-underline": DoubleUnderline,
+	"curly-underline":  CurlyUnderline,
+	"undercurl":        CurlyUnderline,
+}
+
+// parseColorCode 解析颜色描述并返回对应的前景色或背景色代码
+func parseColorCode(spec string, background bool) (string, error) {
+	c, err := ParseColor(spec)
+	if err != nil {
+		return "", err
+	}
+	if background {
+		return c.code("48"), nil
+	}
+	return c.code("38"), nil
+}
@@ -0,0 +1,31 @@
+package goterm
+
+import (
+	"io"
+	"testing"
+)
+
+func TestMarkupFormat(t *testing.T) {
+	link := hyperlinkStart + "https://x.com/a%20b" + hyperlinkEnd + "name" + hyperlinkStart + hyperlinkEnd
+	tests := []struct {
+		name       string
+		hyperlinks bool
+		format     string
+		args       []any
+		want       string
+	}{
+		{"link with percent", true, "[link=https://x.com/a%20b]%s[/]", []any{"name"}, link},
+		{"link fallback", false, "[link=https://x.com/a%20b]%s[/]", []any{"name"}, "name (https://x.com/a%20b)"},
+		{"no arguments", true, "[link=https://x.com/a%20b]name[/]", nil, link},
+		{"literal percent", false, "[bold]%d%%[/]", []any{50}, "50%"},
+		{"brackets in arguments", false, "[bold]%s[/]", []any{"[red]x[/]"}, "[red]x[/]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRenderer(io.Discard).SetColorProfile(ProfileNoColor).SetHyperlinks(tt.hyperlinks)
+			if got := r.Markup(tt.format, tt.args...); got != tt.want {
+				t.Errorf("Markup(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
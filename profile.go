@@ -0,0 +1,334 @@
+package goterm
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// ColorProfile 表示终端支持的颜色能力
+type ColorProfile int
+
+const (
+	// ProfileNoColor 不支持任何颜色和样式
+	ProfileNoColor ColorProfile = iota
+	// ProfileANSI16 支持 16 色
+	ProfileANSI16
+	// ProfileANSI256 支持 256 色
+	ProfileANSI256
+	// ProfileTrueColor 支持 24 位真彩色
+	ProfileTrueColor
+)
+
+// String 返回颜色能力的名称
+func (p ColorProfile) String() string {
+	switch p {
+	case ProfileNoColor:
+		return "NoColor"
+	case ProfileANSI16:
+		return "ANSI16"
+	case ProfileANSI256:
+		return "ANSI256"
+	case ProfileTrueColor:
+		return "TrueColor"
+	default:
+		return "ColorProfile(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// fdWriter 表示带有文件描述符的输出目标，例如 *os.File
+type fdWriter interface {
+	Fd() uintptr
+}
+
+// DetectColorProfile 检测指定输出目标支持的颜色能力：
+// 1. 设置 NO_COLOR 环境变量时不支持颜色
+// 2. 设置 FORCE_COLOR 或 CLICOLOR_FORCE 环境变量时强制启用颜色（FORCE_COLOR 为 0 时强制关闭）
+// 3. 终端为 dumb 终端或输出目标不是终端时不支持颜色
+// 4. 根据 COLORTERM、TERM 等环境变量判断支持的颜色数量
+func DetectColorProfile(w io.Writer) ColorProfile {
+	if os.Getenv("NO_COLOR") != "" {
+		return ProfileNoColor
+	}
+
+	if profile, ok := forcedColorProfile(); ok {
+		return profile
+	}
+
+	if os.Getenv("TERM") == "dumb" {
+		return ProfileNoColor
+	}
+
+	f, ok := w.(fdWriter)
+	if !ok || (!isatty.IsTerminal(f.Fd()) && !isatty.IsCygwinTerminal(f.Fd())) {
+		return ProfileNoColor
+	}
+
+	return envColorProfile()
+}
+
+// forcedColorProfile 根据 FORCE_COLOR 和 CLICOLOR_FORCE 环境变量返回强制使用的颜色能力
+func forcedColorProfile() (ColorProfile, bool) {
+	switch strings.ToLower(os.Getenv("FORCE_COLOR")) {
+	case "":
+	case "0", "false":
+		return ProfileNoColor, true
+	case "2":
+		return ProfileANSI256, true
+	case "3":
+		return ProfileTrueColor, true
+	default:
+		return atLeastANSI16(envColorProfile()), true
+	}
+
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return atLeastANSI16(envColorProfile()), true
+	}
+
+	return ProfileNoColor, false
+}
+
+// atLeastANSI16 确保强制启用颜色时至少支持 16 色
+func atLeastANSI16(p ColorProfile) ColorProfile {
+	if p < ProfileANSI16 {
+		return ProfileANSI16
+	}
+	return p
+}
+
+// envColorProfile 根据环境变量判断终端支持的颜色数量
+func envColorProfile() ColorProfile {
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return ProfileTrueColor
+	}
+
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case strings.HasSuffix(term, "-direct"), strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"):
+		return ProfileTrueColor
+	case strings.Contains(term, "256color"):
+		return ProfileANSI256
+	}
+
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode":
+		return ProfileTrueColor
+	case "Apple_Terminal":
+		return ProfileANSI256
+	}
+
+	if colorTerm != "" {
+		return ProfileANSI256
+	}
+
+	return ProfileANSI16
+}
+
+// Convert 将一个 ANSI 样式代码降级为当前颜色能力支持的代码，
+// 无法表示的代码返回空字符串
+func (p ColorProfile) Convert(code string) string {
+	if p == ProfileNoColor {
+		return ""
+	}
+	if p == ProfileTrueColor {
+		return code
+	}
+
+	// 只处理 SGR 序列（ESC [ ... m）
+	if !strings.HasPrefix(code, "\033[") || !strings.HasSuffix(code, "m") {
+		return code
+	}
+
+	params := strings.Split(code[2:len(code)-1], ";")
+	converted := make([]string, 0, len(params))
+	for i := 0; i < len(params); i++ {
+		param := params[i]
+
+		// 扩展下划线（4:2 双下划线、4:3 波浪下划线等）在 16 色终端上降级为普通下划线
+		if strings.HasPrefix(param, "4:") {
+			if p == ProfileANSI16 {
+				if param == "4:0" {
+					param = "24"
+				} else {
+					param = "4"
+				}
+			}
+			converted = append(converted, param)
+			continue
+		}
+
+		if (param != "38" && param != "48" && param != "58") || i+1 >= len(params) {
+			converted = append(converted, param)
+			continue
+		}
+
+		var color string
+		switch {
+		case params[i+1] == "2" && i+4 < len(params):
+			r, g, b := atoiColor(params[i+2]), atoiColor(params[i+3]), atoiColor(params[i+4])
+			color = p.rgbParams(param, r, g, b)
+			i += 4
+		case params[i+1] == "5" && i+2 < len(params):
+			color = p.indexParams(param, atoiColor(params[i+2]))
+			i += 2
+		default:
+			color = param
+		}
+		if color != "" {
+			converted = append(converted, color)
+		}
+	}
+
+	// 所有参数都被移除时不能输出空序列（ESC [ m 等同于重置）
+	if len(converted) == 0 {
+		return ""
+	}
+	return "\033[" + strings.Join(converted, ";") + "m"
+}
+
+// rgbParams 将 RGB 颜色转换为当前颜色能力支持的 SGR 参数，
+// kind 为 38（前景色）、48（背景色）或 58（下划线颜色）
+func (p ColorProfile) rgbParams(kind string, r, g, b int) string {
+	if p == ProfileANSI256 {
+		return indexedParams(kind, rgbToANSI256(r, g, b))
+	}
+	return basicParams(kind, rgbToANSI16(r, g, b))
+}
+
+// indexParams 将 256 色调色板颜色转换为当前颜色能力支持的 SGR 参数
+func (p ColorProfile) indexParams(kind string, index int) string {
+	switch {
+	case p == ProfileANSI256:
+		return indexedParams(kind, index)
+	case index < 16:
+		return basicParams(kind, index)
+	}
+	r, g, b := ansi256ToRGB(index)
+	return basicParams(kind, rgbToANSI16(r, g, b))
+}
+
+// indexedParams 返回 256 色的 SGR 参数
+func indexedParams(kind string, index int) string {
+	return kind + ";5;" + strconv.Itoa(index)
+}
+
+// basicParams 返回 16 色的 SGR 参数，下划线颜色没有 16 色表示，返回空字符串
+func basicParams(kind string, index int) string {
+	base := 30
+	if index >= 8 {
+		base = 90
+		index -= 8
+	}
+	switch kind {
+	case "48":
+		base += 10
+	case "58":
+		return ""
+	}
+	return strconv.Itoa(base + index)
+}
+
+// atoiColor 解析颜色分量，并限制在 0-255 范围内
+func atoiColor(s string) int {
+	n, _ := strconv.Atoi(s)
+	return clampColor(n)
+}
+
+// clampColor 将颜色分量限制在 0-255 范围内
+func clampColor(n int) int {
+	if n < 0 {
+		return 0
+	}
+	if n > 255 {
+		return 255
+	}
+	return n
+}
+
+// ansi16Palette 标准 16 色对应的 RGB 值（xterm 默认配色）
+var ansi16Palette = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// ansi256Levels 256 色中 6x6x6 色块每个分量的取值
+var ansi256Levels = [6]int{0, 95, 135, 175, 215, 255}
+
+// ansi256ToRGB 返回 256 色调色板中指定索引的 RGB 值
+func ansi256ToRGB(index int) (r, g, b int) {
+	switch {
+	case index < 0:
+		return 0, 0, 0
+	case index < 16:
+		c := ansi16Palette[index]
+		return c[0], c[1], c[2]
+	case index < 232:
+		index -= 16
+		return ansi256Levels[index/36], ansi256Levels[(index/6)%6], ansi256Levels[index%6]
+	case index < 256:
+		gray := 8 + (index-232)*10
+		return gray, gray, gray
+	default:
+		return 255, 255, 255
+	}
+}
+
+// rgbToANSI256 返回与 RGB 颜色最接近的 256 色索引（仅使用色块和灰阶部分）
+func rgbToANSI256(r, g, b int) int {
+	// 最接近的色块颜色
+	ri, gi, bi := nearestLevel(r), nearestLevel(g), nearestLevel(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := colorDistance(r, g, b, ansi256Levels[ri], ansi256Levels[gi], ansi256Levels[bi])
+
+	// 最接近的灰阶颜色
+	grayIndex := ((r+g+b)/3 - 3) / 10
+	if grayIndex < 0 {
+		grayIndex = 0
+	}
+	if grayIndex > 23 {
+		grayIndex = 23
+	}
+	gray := 8 + grayIndex*10
+	grayDist := colorDistance(r, g, b, gray, gray, gray)
+
+	if grayDist < cubeDist {
+		return 232 + grayIndex
+	}
+	return cube
+}
+
+// rgbToANSI16 返回与 RGB 颜色最接近的 16 色索引
+func rgbToANSI16(r, g, b int) int {
+	best, bestDist := 0, -1
+	for i, c := range ansi16Palette {
+		dist := colorDistance(r, g, b, c[0], c[1], c[2])
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+// nearestLevel 返回与颜色分量最接近的色块等级
+func nearestLevel(v int) int {
+	best, bestDist := 0, -1
+	for i, level := range ansi256Levels {
+		dist := (v - level) * (v - level)
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+// colorDistance 计算两个颜色之间的加权距离（考虑人眼对不同颜色的敏感度）
+func colorDistance(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return 2*dr*dr + 4*dg*dg + 3*db*db
+}
@@ -16,7 +16,7 @@ const (
 	BarTypePercent ProgressBarType = iota
 	// BarTypeSpinner 旋转指示器
 	BarTypeSpinner
-	// BarTypeSticky 固定在底部的进度条
+	// BarTypeSticky 固定在底部的进度条（日志在进度条上方滚动）
 	BarTypeSticky
 )
 
@@ -24,7 +24,7 @@ const (
 type ProgressBar struct {
 	Total       int64              // 总进度
 	Current     int64              // 当前进度
-	Width       int                // 进度条宽度
+	Width       int                // 进度条宽度（默认为 0，小于等于 0 时根据终端宽度自动调整，超过终端宽度时缩短）
 	Type        ProgressBarType    // 进度条类型
 	ShowPercent bool               // 是否显示百分比
 	ShowValue   bool               // 是否显示值
@@ -33,13 +33,17 @@ type ProgressBar struct {
 	Spinner     []string           // 旋转指示器字符集
 	Prefix      string             // 前缀
 	Suffix      string             // 后缀
-	Style       *Style             // 样式
+	Style       *Style             // 样式（为空时使用主题中的进度条样式）
+	Theme       *Theme             // 主题（为空时使用全局主题）
+	Gradient    *ColorGradient     // 已完成部分的渐变填充（为空时不使用渐变）
+	term        *Terminal          // 输出进度条的终端，为空时使用默认终端
+	stopResize  func()             // 取消终端尺寸变化订阅
+	footer      *StickyFooter      // 固定进度条所在的底部区域
+	live        *ScreenRenderer    // 百分比进度条和旋转指示器的绘制区域
 	mutex       sync.Mutex         // 互斥锁
 	finished    bool               // 是否已完成
 	spinnerIdx  int                // 当前旋转指示器索引
 	lastPrint   time.Time          // 上次打印时间
-	logLines    []string           // 日志行
-	maxLogLines int                // 最大日志行数
 	logWriter   *progressBarWriter // 日志写入器
 }
 
@@ -48,7 +52,7 @@ func NewProgressBar(total int64) *ProgressBar {
 	return &ProgressBar{
 		Total:       total,
 		Current:     0,
-		Width:       50,
+		Width:       0,
 		Type:        BarTypePercent,
 		ShowPercent: true,
 		ShowValue:   true,
@@ -57,13 +61,11 @@ func NewProgressBar(total int64) *ProgressBar {
 		Spinner:     []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
 		Prefix:      "",
 		Suffix:      "",
-		Style:       New(),
+		Style:       nil,
 		mutex:       sync.Mutex{},
 		finished:    false,
 		spinnerIdx:  0,
 		lastPrint:   time.Now(),
-		logLines:    make([]string, 0),
-		maxLogLines: 10,
 		logWriter:   nil,
 	}
 }
@@ -77,12 +79,12 @@ func NewSpinner() *ProgressBar {
 	return spinner
 }
 
-// NewStickyProgressBar 创建一个固定在底部的进度条
+// NewStickyProgressBar 创建一个固定在底部的进度条。
+// 进度条占据终端底部的一行（通过滚动区域保留），日志在其上方正常滚动并进入终端的滚动历史，
+// 使用结束后需要调用 Finish 恢复终端
 func NewStickyProgressBar(total int64) *ProgressBar {
 	bar := NewProgressBar(total)
 	bar.Type = BarTypeSticky
-	bar.logLines = make([]string, 0)
-	bar.maxLogLines = 10
 	bar.logWriter = &progressBarWriter{bar: bar}
 	return bar
 }
@@ -103,7 +105,7 @@ func (p *ProgressBar) SetEmpty(empty string) *ProgressBar {
 	return p
 }
 
-// SetWidth 设置进度条宽度
+// SetWidth 设置进度条宽度，超过终端宽度时缩短；小于等于 0（默认）时根据终端宽度自动调整，并在终端尺寸变化时重新绘制
 func (p *ProgressBar) SetWidth(width int) *ProgressBar {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
@@ -143,6 +145,40 @@ func (p *ProgressBar) SetStyle(style *Style) *ProgressBar {
 	return p
 }
 
+// SetTheme 设置进度条使用的主题（为空时使用全局主题）
+func (p *ProgressBar) SetTheme(theme *Theme) *ProgressBar {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.Theme = theme
+	return p
+}
+
+// SetGradient 设置已完成部分的渐变填充，例如从绿色渐变到红色
+func (p *ProgressBar) SetGradient(gradient *ColorGradient) *ProgressBar {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.Gradient = gradient
+	return p
+}
+
+// SetTerminal 设置输出进度条的终端（为空时使用默认终端）
+func (p *ProgressBar) SetTerminal(term *Terminal) *ProgressBar {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.term = term
+	return p
+}
+
+// terminal 返回输出进度条的终端
+func (p *ProgressBar) terminal() *Terminal {
+	return terminalOr(p.term)
+}
+
+// style 返回进度条使用的样式（进度条自身的样式优先于主题）
+func (p *ProgressBar) style() *Style {
+	return styleOr(p.Style, themeOr(p.Theme).Progress)
+}
+
 // SetShowPercent 设置是否显示百分比
 func (p *ProgressBar) SetShowPercent(show bool) *ProgressBar {
 	p.mutex.Lock()
@@ -160,10 +196,9 @@ func (p *ProgressBar) SetShowValue(show bool) *ProgressBar {
 }
 
 // SetMaxLogLines 设置最大日志行数
+//
+// Deprecated: 日志直接输出到进度条上方的滚动区域并进入终端的滚动历史，不再限制行数
 func (p *ProgressBar) SetMaxLogLines(max int) *ProgressBar {
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
-	p.maxLogLines = max
 	return p
 }
 
@@ -215,8 +250,17 @@ func (p *ProgressBar) Finish() {
 
 	p.Current = p.Total
 	p.finished = true
+	if p.stopResize != nil {
+		p.stopResize()
+		p.stopResize = nil
+	}
 	p.print(true)
-	fmt.Println()
+	if p.live != nil {
+		p.live.Finish()
+		p.live = nil
+	} else {
+		p.terminal().Println()
+	}
 }
 
 // Start 启动一个旋转指示器并返回停止函数
@@ -246,10 +290,13 @@ func (p *ProgressBar) Start() func() {
 	return func() {
 		close(stop)
 		// 清理行
-		if p.Type == BarTypeSpinner {
-			fmt.Print("\r\033[K")
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		if p.Type == BarTypeSpinner && p.live != nil {
+			p.live.Clear()
+			p.live = nil
 		} else if p.Type == BarTypeSticky {
-			fmt.Print("\r\033[K")
+			p.closeFooter()
 		}
 	}
 }
@@ -277,13 +324,11 @@ func (p *ProgressBar) Log(format string, args ...interface{}) {
 		return
 	}
 
-	message := fmt.Sprintf(format, args...)
-	p.logLines = append(p.logLines, message)
-
-	// 保持日志行数量不超过最大限制
-	if len(p.logLines) > p.maxLogLines {
-		p.logLines = p.logLines[len(p.logLines)-p.maxLogLines:]
+	if p.finished {
+		p.terminal().Println(fmt.Sprintf(format, args...))
+		return
 	}
+	p.stickyFooter().Println(fmt.Sprintf(format, args...))
 
 	// 重新打印
 	p.print(false)
@@ -298,15 +343,22 @@ func (p *ProgressBar) print(force bool) {
 
 	p.lastPrint = time.Now()
 
+	// 进度条的宽度受终端宽度限制，终端尺寸变化时重新绘制
+	if p.stopResize == nil && !p.finished && p.Type != BarTypeSpinner {
+		p.stopResize = p.terminal().OnResize(func(columns, rows int) {
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+			if !p.finished {
+				p.print(true)
+			}
+		})
+	}
+
 	// 根据类型打印不同的进度条
 	switch p.Type {
 	case BarTypePercent:
-		// 清除当前行
-		fmt.Print("\r\033[K")
 		p.printPercentBar()
 	case BarTypeSpinner:
-		// 清除当前行
-		fmt.Print("\r\033[K")
 		p.printSpinner()
 	case BarTypeSticky:
 		p.printStickyBar()
@@ -315,14 +367,33 @@ func (p *ProgressBar) print(force bool) {
 
 // printPercentBar 打印百分比进度条
 func (p *ProgressBar) printPercentBar() {
+	term := p.terminal()
+	p.liveRenderer().RenderLines(p.renderBar(term))
+}
+
+// liveRenderer 返回绘制进度条的 ScreenRenderer，每次更新只重绘发生变化的字符
+func (p *ProgressBar) liveRenderer() *ScreenRenderer {
+	if p.live == nil {
+		p.live = p.terminal().NewScreenRenderer()
+	}
+	return p.live
+}
+
+// renderBar 返回百分比进度条的字符串
+func (p *ProgressBar) renderBar(term *Terminal) string {
+	r := term.Renderer()
 	percent := float64(p.Current) / float64(p.Total) * 100
-	width := p.Width
+	width := p.autoWidth(term, percent)
+	if p.Width > 0 && p.Width < width {
+		width = p.Width
+	}
 
 	// 计算已完成的进度条长度
 	completedWidth := int(float64(width) * float64(p.Current) / float64(p.Total))
 
 	// 构建进度条字符串
 	var bar strings.Builder
+	style := p.style()
 
 	// 添加前缀
 	if p.Prefix != "" {
@@ -331,35 +402,59 @@ func (p *ProgressBar) printPercentBar() {
 
 	// 添加进度条
 	bar.WriteString("[")
+
+	// 渐变填充：已完成部分单独着色，其余部分继续使用进度条样式
+	if p.Gradient != nil && completedWidth > 0 {
+		return r.Sprint(style, bar.String()) +
+			p.Gradient.fill(r, style, p.Fill, completedWidth, width) +
+			r.Sprint(style, p.barTail(width-completedWidth, percent))
+	}
+
 	if completedWidth > 0 {
 		bar.WriteString(strings.Repeat(p.Fill, completedWidth))
 	}
-	if width-completedWidth > 0 {
-		bar.WriteString(strings.Repeat(p.Empty, width-completedWidth))
+	bar.WriteString(p.barTail(width-completedWidth, percent))
+
+	return r.Sprint(style, bar.String())
+}
+
+// autoWidth 返回填满终端宽度时进度条的宽度（至少为 10）
+func (p *ProgressBar) autoWidth(term *Terminal, percent float64) int {
+	width := term.Width() - StringWidth(p.barTail(0, percent)) - 1
+	if p.Prefix != "" {
+		width -= StringWidth(p.Prefix) + 1
+	}
+	// 预留一列，避免光标位于最后一列时终端自动换行
+	width--
+	if width < 10 {
+		width = 10
+	}
+	return width
+}
+
+// barTail 返回进度条未完成部分及其后的百分比、值和后缀
+func (p *ProgressBar) barTail(emptyWidth int, percent float64) string {
+	var tail strings.Builder
+	if emptyWidth > 0 {
+		tail.WriteString(strings.Repeat(p.Empty, emptyWidth))
 	}
-	bar.WriteString("]")
+	tail.WriteString("]")
 
 	// 添加百分比
 	if p.ShowPercent {
-		bar.WriteString(fmt.Sprintf(" %.1f%%", percent))
+		tail.WriteString(fmt.Sprintf(" %.1f%%", percent))
 	}
 
 	// 添加值
 	if p.ShowValue {
-		bar.WriteString(fmt.Sprintf(" %d/%d", p.Current, p.Total))
+		tail.WriteString(fmt.Sprintf(" %d/%d", p.Current, p.Total))
 	}
 
 	// 添加后缀
 	if p.Suffix != "" {
-		bar.WriteString(" " + p.Suffix)
-	}
-
-	// 打印进度条
-	if p.Style != nil {
-		fmt.Print(p.Style.Sprint(bar.String()))
-	} else {
-		fmt.Print(bar.String())
+		tail.WriteString(" " + p.Suffix)
 	}
+	return tail.String()
 }
 
 // printSpinner 打印旋转指示器
@@ -384,80 +479,36 @@ func (p *ProgressBar) printSpinner() {
 	}
 
 	// 打印旋转指示器
-	if p.Style != nil {
-		fmt.Print(p.Style.Sprint(spinner.String()))
-	} else {
-		fmt.Print(spinner.String())
-	}
+	term := p.terminal()
+	p.liveRenderer().RenderLines(term.Sprint(p.style(), spinner.String()))
 }
 
-// printStickyBar 打印固定在底部的进度条和日志
+// printStickyBar 在底部区域中绘制进度条，完成后恢复滚动区域，并把最终的进度条作为普通的一行输出
 func (p *ProgressBar) printStickyBar() {
-	// 保存光标位置
-	fmt.Print("\033[s")
-
-	// 清屏从光标到屏幕底部
-	fmt.Print("\033[J")
-
-	// 打印日志行
-	for _, line := range p.logLines {
-		fmt.Println(line)
+	term := p.terminal()
+	if p.finished {
+		p.closeFooter()
+		term.Print(p.renderBar(term))
+		return
 	}
+	p.stickyFooter().Set(p.renderBar(term))
+}
 
-	// 打印进度条 (使用百分比进度条或旋转指示器的格式)
-	if p.Current < p.Total || !p.finished {
-		if p.Type == BarTypeSticky {
-			// 打印标准百分比进度条
-			percent := float64(p.Current) / float64(p.Total) * 100
-			width := p.Width
-
-			// 计算已完成的进度条长度
-			completedWidth := int(float64(width) * float64(p.Current) / float64(p.Total))
-
-			// 构建进度条字符串
-			var bar strings.Builder
-
-			// 添加前缀
-			if p.Prefix != "" {
-				bar.WriteString(p.Prefix + " ")
-			}
-
-			// 添加进度条
-			bar.WriteString("[")
-			if completedWidth > 0 {
-				bar.WriteString(strings.Repeat(p.Fill, completedWidth))
-			}
-			if width-completedWidth > 0 {
-				bar.WriteString(strings.Repeat(p.Empty, width-completedWidth))
-			}
-			bar.WriteString("]")
-
-			// 添加百分比
-			if p.ShowPercent {
-				bar.WriteString(fmt.Sprintf(" %.1f%%", percent))
-			}
-
-			// 添加值
-			if p.ShowValue {
-				bar.WriteString(fmt.Sprintf(" %d/%d", p.Current, p.Total))
-			}
-
-			// 添加后缀
-			if p.Suffix != "" {
-				bar.WriteString(" " + p.Suffix)
-			}
-
-			// 打印进度条
-			if p.Style != nil {
-				fmt.Print(p.Style.Sprint(bar.String()))
-			} else {
-				fmt.Print(bar.String())
-			}
-		}
+// stickyFooter 返回固定进度条所在的底部区域，第一次调用时在终端底部保留一行
+func (p *ProgressBar) stickyFooter() *StickyFooter {
+	if p.footer == nil {
+		p.footer = p.terminal().NewStickyFooter(1)
 	}
+	return p.footer
+}
 
-	// 恢复光标位置
-	fmt.Print("\033[u")
+// closeFooter 清除固定进度条并恢复滚动区域
+func (p *ProgressBar) closeFooter() {
+	if p.footer != nil {
+		p.footer.Set()
+		p.footer.Close()
+		p.footer = nil
+	}
 }
 
 // GetLogWriter 返回一个可以写入日志的io.Writer接口
@@ -0,0 +1,157 @@
+package goterm
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// RawMode 表示终端原始模式的类型
+type RawMode int
+
+const (
+	// RawModeFull 完全的原始模式：逐字节读取、不回显、不处理输出，
+	// Ctrl-C 等控制字符作为普通按键读取，不会产生信号，需要程序显式处理
+	RawModeFull RawMode = iota
+	// RawModeSignals 与 RawModeFull 相同，但保留 ISIG：Ctrl-C 和 Ctrl-\ 仍然产生信号
+	RawModeSignals
+	// RawModeCbreak 只关闭行缓冲和回显，保留信号和输出处理（\n 会输出为 \r\n）
+	RawModeCbreak
+)
+
+// RestoreOnSignal 控制开启原始模式、全屏会话等需要恢复终端的状态时是否监听 SIGINT 和 SIGTERM（默认为 true）：
+// 收到信号时先恢复终端，再重新发送该信号，使程序按照原来的方式（默认为退出）处理。
+// 程序自己通过 signal.Notify 处理这些信号时（例如全屏程序在退出前询问确认），
+// 会在终端已经被恢复之后再收到一次信号，此时应该设置为 false，并在自己的信号处理中恢复终端
+var RestoreOnSignal = true
+
+// ErrRawModeUnsupported 当前平台或输入源不支持原始模式
+var ErrRawModeUnsupported = errors.New("goterm: raw mode is not supported")
+
+// MakeRaw 将终端的输入源设置为完全的原始模式，返回恢复原来模式的函数
+func (t *Terminal) MakeRaw() (restore func() error, err error) {
+	return t.MakeRawMode(RawModeFull)
+}
+
+// MakeRawMode 将终端的输入源设置为指定的原始模式，返回恢复原来模式的函数。
+// 处于原始模式时收到 SIGINT 或 SIGTERM 会先恢复终端，再重新发送该信号按原来的方式处理；
+// 程序自己处理这些信号时应该将 RestoreOnSignal 设置为 false，否则会收到两次信号。
+// 输入源不是终端设备时返回错误
+func (t *Terminal) MakeRawMode(mode RawMode) (restore func() error, err error) {
+	f, ok := t.in.(*os.File)
+	if !ok {
+		return nil, ErrRawModeUnsupported
+	}
+
+	state, err := makeRaw(f, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := registerCleanup(func() error { return restoreTerminal(f, state) })
+	return entry.run, nil
+}
+
+// WithRawMode 在指定的原始模式下执行 fn，fn 返回或 panic 时都会恢复终端
+func (t *Terminal) WithRawMode(mode RawMode, fn func() error) error {
+	restore, err := t.MakeRawMode(mode)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	return fn()
+}
+
+// cleanupEntry 表示程序退出前需要执行的终端恢复操作，例如退出原始模式、离开备用屏幕
+type cleanupEntry struct {
+	fn   func() error
+	once sync.Once
+	err  error
+}
+
+// run 执行恢复操作（只执行一次）
+func (e *cleanupEntry) run() error {
+	e.once.Do(func() {
+		unregisterCleanup(e)
+		e.err = e.fn()
+	})
+	return e.err
+}
+
+// 尚未执行的恢复操作，收到终止信号时按照注册的相反顺序执行
+var (
+	cleanupMu      sync.Mutex
+	cleanupEntries []*cleanupEntry
+	cleanupSignals chan os.Signal
+)
+
+// registerCleanup 注册恢复操作，第一个恢复操作注册时开始监听终止信号（RestoreOnSignal 为 false 时不监听）
+func registerCleanup(fn func() error) *cleanupEntry {
+	cleanupMu.Lock()
+	defer cleanupMu.Unlock()
+
+	e := &cleanupEntry{fn: fn}
+	cleanupEntries = append(cleanupEntries, e)
+	if cleanupSignals == nil && RestoreOnSignal {
+		if signals := terminationSignals(); len(signals) > 0 {
+			cleanupSignals = make(chan os.Signal, 1)
+			signal.Notify(cleanupSignals, signals...)
+			go handleCleanupSignals(cleanupSignals)
+		}
+	}
+	return e
+}
+
+// terminationSignals 返回需要监听的终止信号。被忽略的信号（例如使用 nohup 启动时的 SIGINT）不监听，
+// 否则 signal.Notify 会取消对它的忽略
+func terminationSignals() []os.Signal {
+	var signals []os.Signal
+	for _, sig := range []os.Signal{os.Interrupt, syscall.SIGTERM} {
+		if !signal.Ignored(sig) {
+			signals = append(signals, sig)
+		}
+	}
+	return signals
+}
+
+// unregisterCleanup 移除已经执行的恢复操作，没有待执行的恢复操作时停止监听终止信号
+func unregisterCleanup(e *cleanupEntry) {
+	cleanupMu.Lock()
+	defer cleanupMu.Unlock()
+
+	for i, entry := range cleanupEntries {
+		if entry == e {
+			cleanupEntries = append(cleanupEntries[:i], cleanupEntries[i+1:]...)
+			break
+		}
+	}
+	if len(cleanupEntries) == 0 && cleanupSignals != nil {
+		signal.Stop(cleanupSignals)
+		close(cleanupSignals)
+		cleanupSignals = nil
+	}
+}
+
+// handleCleanupSignals 收到终止信号时执行所有恢复操作，然后重新发送该信号
+func handleCleanupSignals(ch chan os.Signal) {
+	sig, ok := <-ch
+	if !ok {
+		return
+	}
+
+	cleanupMu.Lock()
+	entries := make([]*cleanupEntry, len(cleanupEntries))
+	copy(entries, cleanupEntries)
+	cleanupMu.Unlock()
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entries[i].run()
+	}
+
+	// 停止监听后重新发送信号，由程序原来的处理方式（默认为退出）处理
+	if p, err := os.FindProcess(os.Getpid()); err == nil {
+		p.Signal(sig)
+	}
+}
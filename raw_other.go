@@ -0,0 +1,18 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package goterm
+
+import "os"
+
+// terminalState 当前平台不支持原始模式
+type terminalState struct{}
+
+// makeRaw 当前平台不支持原始模式
+func makeRaw(f *os.File, mode RawMode) (*terminalState, error) {
+	return nil, ErrRawModeUnsupported
+}
+
+// restoreTerminal 当前平台不支持原始模式
+func restoreTerminal(f *os.File, state *terminalState) error {
+	return nil
+}
@@ -0,0 +1,55 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package goterm
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalState 保存进入原始模式之前的终端设置
+type terminalState struct {
+	termios unix.Termios
+}
+
+// makeRaw 将终端设置为指定的原始模式，返回原来的终端设置
+func makeRaw(f *os.File, mode RawMode) (*terminalState, error) {
+	fd := int(f.Fd())
+	termios, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+	state := &terminalState{termios: *termios}
+
+	raw := *termios
+	switch mode {
+	case RawModeCbreak:
+		raw.Lflag &^= unix.ICANON | unix.ECHO
+	default:
+		// 与 cfmakeraw 相同
+		raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+		raw.Oflag &^= unix.OPOST
+		raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+		raw.Cflag &^= unix.CSIZE | unix.PARENB
+		raw.Cflag |= unix.CS8
+		if mode == RawModeSignals {
+			raw.Lflag |= unix.ISIG
+		}
+	}
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// restoreTerminal 恢复原来的终端设置
+func restoreTerminal(f *os.File, state *terminalState) error {
+	if state == nil {
+		return nil
+	}
+	return unix.IoctlSetTermios(int(f.Fd()), ioctlSetTermios, &state.termios)
+}
@@ -0,0 +1,231 @@
+package goterm
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-isatty"
+)
+
+// Renderer 表示绑定到具体输出目标的渲染器，
+// 根据该输出目标（是否为终端、NO_COLOR、FORCE_COLOR、CLICOLOR_FORCE）决定颜色能力
+type Renderer struct {
+	w       io.Writer    // 输出目标
+	profile ColorProfile // 颜色能力
+
+	backgroundOnce sync.Once // 终端背景只在第一次使用自适应颜色时检测
+	darkBackground bool      // 终端背景是否为深色
+
+	hyperlinks *bool // 是否输出 OSC 8 超链接，为空时自动检测
+}
+
+// NewRenderer 创建一个绑定到指定输出目标的渲染器
+func NewRenderer(w io.Writer) *Renderer {
+	return &Renderer{
+		w:       w,
+		profile: DetectColorProfile(w),
+	}
+}
+
+// 默认渲染器，绑定到全局输出目标 Output
+var (
+	defaultRenderer   *Renderer
+	defaultRendererMu sync.Mutex
+)
+
+// DefaultRenderer 返回绑定到全局输出目标 Output 的渲染器
+func DefaultRenderer() *Renderer {
+	defaultRendererMu.Lock()
+	defer defaultRendererMu.Unlock()
+
+	if defaultRenderer == nil || !sameWriter(defaultRenderer.w, Output) {
+		defaultRenderer = NewRenderer(Output)
+	}
+	return defaultRenderer
+}
+
+// SetDefaultRenderer 设置默认渲染器，同时将全局输出目标设置为该渲染器的输出目标
+func SetDefaultRenderer(r *Renderer) {
+	defaultRendererMu.Lock()
+	defer defaultRendererMu.Unlock()
+
+	Output = r.w
+	defaultRenderer = r
+}
+
+// rendererFor 返回输出到指定 writer 时使用的渲染器
+func rendererFor(w io.Writer) *Renderer {
+	if r, ok := w.(*Renderer); ok {
+		return r
+	}
+	if sameWriter(w, Output) {
+		return DefaultRenderer()
+	}
+	return NewRenderer(w)
+}
+
+// sameWriter 判断两个输出目标是否相同（不可比较的类型视为不同）
+func sameWriter(a, b io.Writer) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// Writer 返回渲染器的输出目标
+func (r *Renderer) Writer() io.Writer {
+	return r.w
+}
+
+// ColorProfile 返回渲染器使用的颜色能力
+func (r *Renderer) ColorProfile() ColorProfile {
+	if NoColor {
+		return ProfileNoColor
+	}
+	return r.profile
+}
+
+// SetColorProfile 设置渲染器使用的颜色能力
+func (r *Renderer) SetColorProfile(profile ColorProfile) *Renderer {
+	r.profile = profile
+	return r
+}
+
+// HasDarkBackground 返回终端背景是否为深色。
+// 第一次调用时检测终端背景（输出目标不是终端时只使用 COLORFGBG），检测失败时视为深色背景
+func (r *Renderer) HasDarkBackground() bool {
+	r.backgroundOnce.Do(func() {
+		r.darkBackground = true
+		if !r.isTerminal() {
+			if c, ok := envBackgroundColor(); ok {
+				r.darkBackground = c.IsDark()
+			}
+			return
+		}
+		if c, ok := cachedBackgroundColor(); ok {
+			r.darkBackground = c.IsDark()
+		}
+	})
+	return r.darkBackground
+}
+
+// SetHasDarkBackground 设置终端背景是否为深色，跳过自动检测
+func (r *Renderer) SetHasDarkBackground(dark bool) *Renderer {
+	r.backgroundOnce.Do(func() {})
+	r.darkBackground = dark
+	return r
+}
+
+// isTerminal 返回渲染器的输出目标是否为终端
+func (r *Renderer) isTerminal() bool {
+	f, ok := r.w.(fdWriter)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+// darkFor 返回渲染指定样式时使用的背景，样式不包含自适应颜色时不检测终端背景
+func (r *Renderer) darkFor(style *Style) bool {
+	if !style.isAdaptive() || !r.HasColor() {
+		return true
+	}
+	return r.HasDarkBackground()
+}
+
+// HasColor 返回渲染器是否输出颜色
+func (r *Renderer) HasColor() bool {
+	return r.ColorProfile() != ProfileNoColor
+}
+
+// Sprint 返回使用指定样式渲染的字符串
+func (r *Renderer) Sprint(style *Style, a ...any) string {
+	if style == nil {
+		return fmt.Sprint(a...)
+	}
+	return r.renderStyle(style, fmt.Sprint(a...))
+}
+
+// Sprintf 返回使用指定样式和格式渲染的字符串
+func (r *Renderer) Sprintf(style *Style, format string, a ...any) string {
+	if style == nil {
+		return fmt.Sprintf(format, a...)
+	}
+	return r.renderStyle(style, fmt.Sprintf(format, a...))
+}
+
+// renderStyle 为文本应用样式，样式包含超链接时再添加超链接
+func (r *Renderer) renderStyle(style *Style, text string) string {
+	styled := style.render(r.ColorProfile(), r.darkFor(style), text)
+	if url := style.linkURL(); url != "" {
+		return r.link(styled, text, url)
+	}
+	return styled
+}
+
+// Print 输出文本
+func (r *Renderer) Print(a ...any) (n int, err error) {
+	return fmt.Fprint(r, a...)
+}
+
+// Println 输出文本并换行
+func (r *Renderer) Println(a ...any) (n int, err error) {
+	return fmt.Fprintln(r, a...)
+}
+
+// Printf 输出格式化文本
+func (r *Renderer) Printf(format string, a ...any) (n int, err error) {
+	return fmt.Fprintf(r, format, a...)
+}
+
+// Write 实现 io.Writer 接口，写入内容中的样式代码会被转换为渲染器支持的颜色能力
+func (r *Renderer) Write(p []byte) (n int, err error) {
+	profile := r.ColorProfile()
+	if profile == ProfileTrueColor || !strings.Contains(string(p), "\033[") {
+		return r.w.Write(p)
+	}
+
+	if _, err := io.WriteString(r.w, profile.ConvertString(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ConvertString 将字符串中所有的样式代码转换为当前颜色能力支持的代码，
+// 不支持颜色时移除所有样式代码（光标控制等其他转义序列保持不变）
+func (p ColorProfile) ConvertString(s string) string {
+	if p == ProfileTrueColor {
+		return s
+	}
+
+	var sb strings.Builder
+	for {
+		start := strings.Index(s, "\033[")
+		if start < 0 {
+			break
+		}
+
+		// 查找 CSI 序列的结束字符
+		end := start + 2
+		for end < len(s) && (s[end] < 0x40 || s[end] > 0x7E) {
+			end++
+		}
+		if end >= len(s) {
+			break
+		}
+
+		sb.WriteString(s[:start])
+		if s[end] == 'm' {
+			sb.WriteString(p.Convert(s[start : end+1]))
+		} else {
+			sb.WriteString(s[start : end+1])
+		}
+		s = s[end+1:]
+	}
+	sb.WriteString(s)
+
+	return sb.String()
+}
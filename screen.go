@@ -0,0 +1,241 @@
+package goterm
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Cell 表示屏幕上的一个单元格
+type Cell struct {
+	Rune      rune   // 单元格中的字符
+	Combining string // 附加在字符后面的组合字符、变体选择符和零宽连接的表情符号序列
+	Width     int    // 字符的显示宽度，宽字符占两个单元格，第二个单元格的宽度为 0
+	Style     string // 字符的样式代码（SGR 转义序列），为空时使用终端的默认样式
+	Link      string // 字符所属超链接的地址，为空时不是超链接
+}
+
+// blankCell 空白单元格
+var blankCell = Cell{Rune: ' ', Width: 1}
+
+// isBlank 返回单元格是否为没有样式的空白
+func (c Cell) isBlank() bool {
+	return c == blankCell
+}
+
+// Screen 表示内存中的单元格网格，是实时组件（进度条、选择框、动画）的绘制目标。
+// 组件先在 Screen 上绘制一帧，再由 ScreenRenderer 与上一帧比较，只输出变化的部分
+type Screen struct {
+	width  int    // 宽度（列数）
+	height int    // 高度（行数）
+	cells  []Cell // 按行存储的单元格
+}
+
+// NewScreen 创建一个指定宽度和高度的空白屏幕
+func NewScreen(width, height int) *Screen {
+	s := &Screen{}
+	s.Resize(width, height)
+	return s
+}
+
+// Width 返回屏幕的宽度
+func (s *Screen) Width() int {
+	return s.width
+}
+
+// Height 返回屏幕的高度
+func (s *Screen) Height() int {
+	return s.height
+}
+
+// Resize 调整屏幕的尺寸，保留两者重叠部分的内容
+func (s *Screen) Resize(width, height int) {
+	if width < 0 {
+		width = 0
+	}
+	if height < 0 {
+		height = 0
+	}
+
+	cells := make([]Cell, width*height)
+	for i := range cells {
+		cells[i] = blankCell
+	}
+	for y := 0; y < height && y < s.height; y++ {
+		for x := 0; x < width && x < s.width; x++ {
+			cells[y*width+x] = s.cells[y*s.width+x]
+		}
+	}
+
+	s.width, s.height, s.cells = width, height, cells
+}
+
+// Clear 将屏幕上的所有单元格设置为空白
+func (s *Screen) Clear() {
+	for i := range s.cells {
+		s.cells[i] = blankCell
+	}
+}
+
+// Cell 返回指定位置（从 0 开始）的单元格，超出屏幕范围时返回空白单元格
+func (s *Screen) Cell(x, y int) Cell {
+	if x < 0 || y < 0 || x >= s.width || y >= s.height {
+		return blankCell
+	}
+	return s.cells[y*s.width+x]
+}
+
+// SetCell 设置指定位置（从 0 开始）的单元格，超出屏幕范围时忽略
+func (s *Screen) SetCell(x, y int, c Cell) {
+	if x < 0 || y < 0 || x >= s.width || y >= s.height {
+		return
+	}
+	s.cells[y*s.width+x] = c
+}
+
+// SetString 从指定位置开始在一行中写入文本，返回写入的显示宽度。
+// 文本中的样式代码和超链接会记录到单元格中，超出屏幕宽度的部分被截断，遇到换行符时停止
+func (s *Screen) SetString(x, y int, text string) int {
+	if y < 0 || y >= s.height {
+		return 0
+	}
+
+	start := x
+	style, link := "", ""
+	for i := 0; i < len(text) && x < s.width; {
+		if n := escapeLength(text[i:]); n > 0 {
+			style, link = applyEscape(style, link, text[i:i+n])
+			i += n
+			continue
+		}
+
+		// 按字素簇写入，组合字符和表情符号序列与前面的字符占用同一个单元格
+		size, width := nextGrapheme(text[i:])
+		cluster := text[i : i+size]
+		i += size
+		if cluster == "\n" {
+			break
+		}
+		if width == 0 {
+			// 跳过控制字符（它们会移动终端的光标），单独出现的组合字符附加到前一个字符上
+			if c := cluster[0]; c >= ' ' && c != 0x7F {
+				s.combine(x, y, cluster)
+			}
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(cluster)
+		if x+width > s.width {
+			// 宽字符放不下时用空白填充剩余的位置
+			s.SetCell(x, y, blankCell)
+			x++
+			break
+		}
+		if x >= 0 {
+			s.cells[y*s.width+x] = Cell{Rune: r, Combining: cluster[n:], Width: width, Style: style, Link: link}
+			for j := 1; j < width; j++ {
+				s.cells[y*s.width+x+j] = Cell{Width: 0, Style: style, Link: link}
+			}
+		}
+		x += width
+	}
+	return x - start
+}
+
+// combine 将零宽字符附加到 x 前面的字符上
+func (s *Screen) combine(x, y int, text string) {
+	for x--; x >= 0 && x < s.width; x-- {
+		if c := &s.cells[y*s.width+x]; c.Width > 0 {
+			c.Combining += text
+			return
+		}
+	}
+}
+
+// SetLine 清除一行并写入文本
+func (s *Screen) SetLine(y int, text string) {
+	if y < 0 || y >= s.height {
+		return
+	}
+	for x := 0; x < s.width; x++ {
+		s.cells[y*s.width+x] = blankCell
+	}
+	s.SetString(0, y, text)
+}
+
+// SetLines 清除屏幕并从第一行开始按行写入文本
+func (s *Screen) SetLines(lines ...string) {
+	s.Clear()
+	for y, line := range lines {
+		s.SetString(0, y, line)
+	}
+}
+
+// Line 返回一行的内容，包含样式代码和超链接，行尾的空白被去除
+func (s *Screen) Line(y int) string {
+	if y < 0 || y >= s.height {
+		return ""
+	}
+	row := s.cells[y*s.width : (y+1)*s.width]
+
+	end := len(row)
+	for end > 0 && row[end-1].isBlank() {
+		end--
+	}
+
+	var out strings.Builder
+	var pen penState
+	for _, c := range row[:end] {
+		if c.Width == 0 {
+			continue
+		}
+		pen.set(&out, c)
+		out.WriteRune(c.Rune)
+		out.WriteString(c.Combining)
+	}
+	pen.set(&out, blankCell)
+	return out.String()
+}
+
+// String 返回屏幕的全部内容，每行之间使用换行符分隔
+func (s *Screen) String() string {
+	lines := make([]string, s.height)
+	for y := range lines {
+		lines[y] = s.Line(y)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// applyEscape 根据转义序列更新当前的样式和超链接，其他转义序列被忽略
+func applyEscape(style, link, seq string) (string, string) {
+	if isLink, url := hyperlinkURL(seq); isLink {
+		return style, url
+	}
+	if strings.HasPrefix(seq, "\033[") && strings.HasSuffix(seq, "m") {
+		if seq == Reset || seq == "\033[m" {
+			return "", link
+		}
+		return style + seq, link
+	}
+	return style, link
+}
+
+// penState 记录输出时终端当前使用的样式和超链接，只在变化时输出转义序列
+type penState struct {
+	style string
+	link  string
+}
+
+// set 切换到单元格的样式和超链接
+func (p *penState) set(out *strings.Builder, c Cell) {
+	if c.Style != p.style {
+		if p.style != "" {
+			out.WriteString(Reset)
+		}
+		out.WriteString(c.Style)
+		p.style = c.Style
+	}
+	if c.Link != p.link {
+		out.WriteString(hyperlinkStart + c.Link + hyperlinkEnd)
+		p.link = c.Link
+	}
+}
@@ -0,0 +1,309 @@
+package goterm
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 同步输出（模式 2026）：终端在收到结束序列之前不刷新画面，一帧的内容一次性显示
+const (
+	syncOutputStart = "\033[?2026h"
+	syncOutputEnd   = "\033[?2026l"
+)
+
+// SyncOutputQueryTimeout 查询终端是否支持同步输出时等待终端响应的最长时间
+var SyncOutputQueryTimeout = 100 * time.Millisecond
+
+// SupportsSyncOutput 返回终端是否支持同步输出（模式 2026）。
+// 没有通过 SetSyncOutput 或 DetectSyncOutput 设置时根据环境变量判断，不会向终端发送查询，
+// 输出目标不是终端时视为不支持
+func (t *Terminal) SupportsSyncOutput() bool {
+	t.syncOnce.Do(func() {
+		t.syncOutput = t.IsTerminal() && detectSyncOutput()
+	})
+	return t.syncOutput
+}
+
+// SetSyncOutput 设置终端是否支持同步输出，跳过自动检测
+func (t *Terminal) SetSyncOutput(enabled bool) *Terminal {
+	t.syncOnce.Do(func() {})
+	t.syncOutput = enabled
+	return t
+}
+
+// DetectSyncOutput 通过 DECRQM 查询终端是否支持同步输出，并记录查询结果。
+// 查询期间终端处于原始模式并读取输入，应当在程序开始读取输入之前调用；
+// 输出目标不是终端或终端没有响应时视为不支持
+func (t *Terminal) DetectSyncOutput() bool {
+	supported := false
+	if t.IsTerminal() && os.Getenv("TERM") != "dumb" {
+		response, err := t.query("\033[?2026$p\033[c", SyncOutputQueryTimeout, deviceAttributesReceived)
+		// 响应格式为 ESC [ ? 2026 ; 状态 $ y，状态为 1（开启）或 2（关闭）表示支持
+		i := strings.Index(response, "\033[?2026;")
+		if err == nil && i >= 0 && i+len("\033[?2026;") < len(response) {
+			state := response[i+len("\033[?2026;")]
+			supported = state == '1' || state == '2'
+		}
+	}
+	t.SetSyncOutput(supported)
+	return supported
+}
+
+// detectSyncOutput 根据环境变量判断终端是否支持同步输出。
+// 不支持的终端会忽略同步输出的序列，因此这里只列出已知支持的终端
+func detectSyncOutput() bool {
+	// 终端复用器和 CI 环境中的支持情况不确定
+	if os.Getenv("CI") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen") || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "ghostty", "contour", "rio":
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" {
+		return true
+	}
+
+	switch os.Getenv("TERM") {
+	case "xterm-kitty", "alacritty", "foot", "xterm-ghostty", "wezterm", "contour":
+		return true
+	}
+	return false
+}
+
+// ScreenRenderer 将 Screen 输出到终端：与上一帧比较，只输出变化的单元格和必要的光标移动。
+// 绘制区域从第一次输出时光标所在行的行首开始，向下延伸到 Screen 的高度，
+// 终端支持同步输出时每一帧都包裹在同步输出中，避免通过 SSH 重绘时闪烁
+type ScreenRenderer struct {
+	term  *Terminal  // 输出的终端
+	front *Screen    // 终端上当前显示的内容，为空时下一帧完整绘制
+	x, y  int        // 光标相对于绘制区域左上角的位置
+	rows  int        // 绘制区域已经占用的行数
+	pen   penState   // 终端当前使用的样式和超链接
+	mutex sync.Mutex // 互斥锁
+}
+
+// NewScreenRenderer 创建一个输出到该终端的 ScreenRenderer，绘制区域从当前光标所在的行开始
+func (t *Terminal) NewScreenRenderer() *ScreenRenderer {
+	return &ScreenRenderer{term: t, rows: 1}
+}
+
+// Terminal 返回输出的终端
+func (r *ScreenRenderer) Terminal() *Terminal {
+	return r.term
+}
+
+// Render 输出一帧，只重绘与上一帧不同的单元格。
+// 宽度变化时（通常是终端尺寸改变导致内容重新换行）清除绘制区域并完整绘制
+func (r *ScreenRenderer) Render(s *Screen) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var out strings.Builder
+	if r.front != nil && r.front.width != s.width {
+		r.moveTo(&out, 0, 0)
+		out.WriteString("\033[J")
+		r.front = nil
+	}
+
+	for y := 0; y < s.height; y++ {
+		var old []Cell
+		if r.front != nil && y < r.front.height {
+			old = r.front.cells[y*r.front.width : (y+1)*r.front.width]
+		}
+		r.renderRow(&out, s, y, old)
+	}
+
+	// 清除上一帧中多出来的行
+	if r.front != nil {
+		for y := s.height; y < r.front.height; y++ {
+			r.clearLine(&out, 0, y)
+		}
+	}
+	r.pen.set(&out, blankCell)
+
+	r.front = &Screen{width: s.width, height: s.height, cells: append([]Cell(nil), s.cells...)}
+	r.flush(&out)
+}
+
+// RenderLines 将每个参数作为一行输出，宽度为终端宽度减一（预留最后一列，避免终端自动换行）
+func (r *ScreenRenderer) RenderLines(lines ...string) {
+	s := NewScreen(r.term.Width()-1, len(lines))
+	s.SetLines(lines...)
+	r.Render(s)
+}
+
+// Invalidate 丢弃记录的上一帧，下一帧完整绘制（例如绘制区域被其他输出覆盖之后）
+func (r *ScreenRenderer) Invalidate() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.front = nil
+}
+
+// Clear 清除绘制区域并把光标移回区域的左上角
+func (r *ScreenRenderer) Clear() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var out strings.Builder
+	r.moveTo(&out, 0, 0)
+	out.WriteString("\033[J")
+	r.front = nil
+	r.flush(&out)
+}
+
+// Finish 将光标移动到绘制区域下一行的行首，之后的输出不会覆盖已经绘制的内容。
+// 再次调用 Render 时从当前行开始一个新的绘制区域
+func (r *ScreenRenderer) Finish() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.front == nil {
+		return
+	}
+	var out strings.Builder
+	r.moveTo(&out, 0, r.front.height)
+	r.flush(&out)
+
+	r.front = nil
+	r.x, r.y, r.rows = 0, 0, 1
+}
+
+// renderRow 输出一行中变化的部分，old 为空时完整绘制该行
+func (r *ScreenRenderer) renderRow(out *strings.Builder, s *Screen, y int, old []Cell) {
+	row := s.cells[y*s.width : (y+1)*s.width]
+	end := len(row)
+	for end > 0 && row[end-1].isBlank() {
+		end--
+	}
+
+	if old == nil {
+		r.writeCells(out, row, 0, end, y)
+		if end < len(row) {
+			r.clearLine(out, end, y)
+		}
+		return
+	}
+
+	for x := 0; x < len(row); {
+		if row[x] == old[x] {
+			x++
+			continue
+		}
+
+		start := x
+		for start > 0 && row[start].Width == 0 {
+			start-- // 从宽字符的第一个单元格开始重绘
+		}
+		if start >= end {
+			// 该行剩余的部分都是空白，直接清除到行尾
+			r.clearLine(out, start, y)
+			return
+		}
+
+		// 间隔很短的两个变化区间合并输出，比移动光标更省字节
+		stop := x + 1
+		for stop < end {
+			if row[stop] != old[stop] {
+				stop++
+				continue
+			}
+			gap := 0
+			for stop+gap < end && row[stop+gap] == old[stop+gap] && gap < 4 {
+				gap++
+			}
+			if gap < 4 && stop+gap < end {
+				stop += gap
+				continue
+			}
+			break
+		}
+		for stop < len(row) && row[stop].Width == 0 {
+			stop++ // 包含宽字符的第二个单元格
+		}
+
+		r.writeCells(out, row, start, stop, y)
+		x = stop
+	}
+}
+
+// writeCells 输出一行中 [start, stop) 范围内的单元格
+func (r *ScreenRenderer) writeCells(out *strings.Builder, row []Cell, start, stop, y int) {
+	if start >= stop {
+		return
+	}
+	r.moveTo(out, start, y)
+	for _, c := range row[start:stop] {
+		if c.Width == 0 {
+			continue
+		}
+		r.pen.set(out, c)
+		out.WriteRune(c.Rune)
+		out.WriteString(c.Combining)
+		r.x += c.Width
+	}
+}
+
+// clearLine 从指定位置清除到行尾
+func (r *ScreenRenderer) clearLine(out *strings.Builder, x, y int) {
+	r.moveTo(out, x, y)
+	// 清除时使用当前的背景色，因此先恢复默认样式
+	r.pen.set(out, blankCell)
+	out.WriteString("\033[K")
+}
+
+// moveTo 使用相对移动把光标移动到绘制区域中的指定位置，
+// 需要的行超出已经占用的范围时输出换行（光标位于屏幕底部时内容会向上滚动）
+func (r *ScreenRenderer) moveTo(out *strings.Builder, x, y int) {
+	if y > r.y {
+		// 已经占用的行使用光标移动，不会滚动屏幕
+		target := y
+		if target > r.rows-1 {
+			target = r.rows - 1
+		}
+		if target > r.y {
+			fmt.Fprintf(out, "\033[%dB", target-r.y)
+			r.y = target
+		}
+		if r.y < y {
+			r.pen.set(out, blankCell)
+		}
+		for r.y < y {
+			// 原始模式下换行不会回到行首，因此显式输出回车
+			out.WriteString("\r\n")
+			r.y++
+			r.x = 0
+		}
+		if r.y+1 > r.rows {
+			r.rows = r.y + 1
+		}
+	} else if y < r.y {
+		fmt.Fprintf(out, "\033[%dA", r.y-y)
+		r.y = y
+	}
+
+	if x != r.x {
+		if x == 0 {
+			out.WriteString("\r")
+		} else {
+			fmt.Fprintf(out, "\033[%dG", x+1)
+		}
+		r.x = x
+	}
+}
+
+// flush 一次性输出一帧的内容，终端支持时包裹在同步输出中
+func (r *ScreenRenderer) flush(out *strings.Builder) {
+	if out.Len() == 0 {
+		return
+	}
+	if r.term.SupportsSyncOutput() {
+		r.term.Print(syncOutputStart + out.String() + syncOutputEnd)
+		return
+	}
+	r.term.Print(out.String())
+}
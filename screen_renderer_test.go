@@ -0,0 +1,53 @@
+package goterm
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDetectSyncOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		term        string
+		termProgram string
+		ci          string
+		want        bool
+	}{
+		{"unknown", "xterm-256color", "", "", false},
+		{"kitty", "xterm-kitty", "", "", true},
+		{"wezterm", "xterm-256color", "WezTerm", "", true},
+		{"tmux", "screen-256color", "WezTerm", "", false},
+		{"ci", "xterm-kitty", "", "true", false},
+		{"dumb", "dumb", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TERM", tt.term)
+			t.Setenv("TERM_PROGRAM", tt.termProgram)
+			t.Setenv("CI", tt.ci)
+			t.Setenv("WT_SESSION", "")
+			if got := detectSyncOutput(); got != tt.want {
+				t.Errorf("detectSyncOutput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScreenRendererSyncOutput(t *testing.T) {
+	t.Setenv("TERM", "xterm-kitty")
+
+	// 输出目标不是终端时不使用同步输出，也不会查询终端
+	var buf bytes.Buffer
+	term := NewTerminal(&buf)
+	term.NewScreenRenderer().RenderLines("a")
+	if bytes.Contains(buf.Bytes(), []byte(syncOutputStart)) {
+		t.Errorf("output = %q, want no synchronized output", buf.String())
+	}
+
+	buf.Reset()
+	term = NewTerminal(&buf).SetSyncOutput(true)
+	term.NewScreenRenderer().RenderLines("a")
+	if got := buf.String(); got != syncOutputStart+"a\x1b[K"+syncOutputEnd {
+		t.Errorf("output = %q", got)
+	}
+}
@@ -0,0 +1,135 @@
+package goterm
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// 终端尺寸无法获取时使用的默认值
+const (
+	defaultColumns = 80
+	defaultRows    = 24
+)
+
+// sizedWriter 是能够报告自身尺寸的输出目标，例如 vt 包中的虚拟终端
+type sizedWriter interface {
+	Size() (columns, rows int)
+}
+
+// Size 返回终端的列数和行数。
+// 输出目标能够报告自身尺寸时（例如虚拟终端）使用该尺寸，
+// 否则依次尝试通过输出目标和输入源查询终端尺寸，都不是终端时使用 COLUMNS 和 LINES 环境变量
+func (t *Terminal) Size() (columns, rows int, err error) {
+	if s, ok := t.Writer().(sizedWriter); ok {
+		columns, rows := s.Size()
+		return columns, rows, nil
+	}
+	if f, ok := t.Writer().(fdWriter); ok {
+		if columns, rows, err := ioctlSize(f.Fd()); err == nil && columns > 0 {
+			return columns, rows, nil
+		}
+	}
+	if f, ok := t.in.(fdWriter); ok {
+		if columns, rows, err := ioctlSize(f.Fd()); err == nil && columns > 0 {
+			return columns, rows, nil
+		}
+	}
+	return envSize()
+}
+
+// Width 返回终端的列数，无法获取时返回 80
+func (t *Terminal) Width() int {
+	columns, _, err := t.Size()
+	if err != nil || columns <= 0 {
+		return defaultColumns
+	}
+	return columns
+}
+
+// Height 返回终端的行数，无法获取时返回 24
+func (t *Terminal) Height() int {
+	_, rows, err := t.Size()
+	if err != nil || rows <= 0 {
+		return defaultRows
+	}
+	return rows
+}
+
+// outputWidth 返回渲染器输出目标的列数，用于限制表格和图表的宽度。
+// 只有输出到终端设备或能够报告自身尺寸的输出目标时才有限制，其他情况（例如管道和文件）返回 0
+func outputWidth(r *Renderer) int {
+	t := NewTerminal(r)
+	if _, ok := r.Writer().(sizedWriter); !ok && !t.IsTerminal() {
+		return 0
+	}
+	return t.Width()
+}
+
+// TerminalSize 返回默认终端的列数和行数
+func TerminalSize() (columns, rows int, err error) {
+	return DefaultTerminal().Size()
+}
+
+// envSize 根据 COLUMNS 和 LINES 环境变量获取终端尺寸
+func envSize() (columns, rows int, err error) {
+	columns, err = strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || columns <= 0 {
+		return 0, 0, errors.New("goterm: unable to determine terminal size")
+	}
+	rows, err = strconv.Atoi(os.Getenv("LINES"))
+	if err != nil || rows <= 0 {
+		rows = 0
+	}
+	return columns, rows, nil
+}
+
+// 终端尺寸变化的订阅者
+var (
+	resizeMu       sync.Mutex
+	resizeHandlers = map[int]func(){}
+	resizeNextID   int
+	resizeStarted  bool
+)
+
+// OnResize 订阅终端尺寸变化，窗口大小改变时使用新的列数和行数调用 fn（在单独的 goroutine 中调用）。
+// 返回取消订阅的函数。不支持尺寸变化通知的平台上 fn 不会被调用
+func (t *Terminal) OnResize(fn func(columns, rows int)) (cancel func()) {
+	resizeMu.Lock()
+	defer resizeMu.Unlock()
+
+	if !resizeStarted {
+		resizeStarted = true
+		watchResize(dispatchResize)
+	}
+
+	id := resizeNextID
+	resizeNextID++
+	resizeHandlers[id] = func() {
+		columns, rows, err := t.Size()
+		if err == nil {
+			fn(columns, rows)
+		}
+	}
+
+	return func() {
+		resizeMu.Lock()
+		defer resizeMu.Unlock()
+		delete(resizeHandlers, id)
+	}
+}
+
+// dispatchResize 通知所有订阅者终端尺寸已经变化
+func dispatchResize() {
+	resizeMu.Lock()
+	handlers := make([]func(), 0, len(resizeHandlers))
+	for _, handler := range resizeHandlers {
+		handlers = append(handlers, handler)
+	}
+	resizeMu.Unlock()
+
+	for _, handler := range handlers {
+		handler()
+	}
+}
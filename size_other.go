@@ -0,0 +1,13 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package goterm
+
+import "errors"
+
+// ioctlSize 当前平台不支持查询终端尺寸
+func ioctlSize(fd uintptr) (columns, rows int, err error) {
+	return 0, 0, errors.New("goterm: terminal size query not supported on this platform")
+}
+
+// watchResize 当前平台不支持终端尺寸变化通知
+func watchResize(notify func()) {}
@@ -0,0 +1,125 @@
+package goterm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// sizedBuffer 是能够报告自身尺寸的缓冲区，相当于指定宽度的终端
+type sizedBuffer struct {
+	bytes.Buffer
+	width int
+}
+
+func (b *sizedBuffer) Size() (columns, rows int) {
+	return b.width, 24
+}
+
+// maxLineWidth 返回输出中最宽的一行的显示宽度
+func maxLineWidth(s string) int {
+	max := 0
+	for _, line := range strings.Split(s, "\n") {
+		if w := StringWidth(line); w > max {
+			max = w
+		}
+	}
+	return max
+}
+
+func TestOutputWidth(t *testing.T) {
+	if got := outputWidth(NewRenderer(&bytes.Buffer{})); got != 0 {
+		t.Errorf("outputWidth(buffer) = %d, want 0", got)
+	}
+	if got := outputWidth(NewRenderer(&sizedBuffer{width: 33})); got != 33 {
+		t.Errorf("outputWidth(sized) = %d, want 33", got)
+	}
+}
+
+func TestTableFitsTerminal(t *testing.T) {
+	table := NewEmptyTable().AddColumn(NewColumn("名称")).AddColumn(NewColumn("描述"))
+	table.AddRow("goterm", "a terminal styling and layout library for Go programs")
+	table.AddRow("vt", "headless terminal emulator")
+
+	tests := []struct {
+		name      string
+		hasBorder bool
+		width     int
+	}{
+		{"border", true, 30},
+		{"no border", false, 30},
+		{"very narrow", true, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table.SetHasBorder(tt.hasBorder)
+			out := &sizedBuffer{width: tt.width}
+			table.Fprint(out)
+			want := tt.width
+			if min := table.decorationWidth() + 3*len(table.Columns); want < min {
+				want = min
+			}
+			if got := maxLineWidth(out.String()); got != want {
+				t.Errorf("table width = %d, want %d:\n%s", got, want, out.String())
+			}
+			if !strings.Contains(out.String(), "...") {
+				t.Errorf("long cell not truncated:\n%s", out.String())
+			}
+
+			styled := &sizedBuffer{width: tt.width}
+			table.FprintWithStyle(styled)
+			if got := maxLineWidth(styled.String()); got != want {
+				t.Errorf("styled table width = %d, want %d", got, want)
+			}
+		})
+	}
+
+	// 不是终端时不限制宽度
+	var buf bytes.Buffer
+	table.SetHasBorder(true).Fprint(&buf)
+	if strings.Contains(buf.String(), "...") {
+		t.Errorf("table truncated when not writing to a terminal:\n%s", buf.String())
+	}
+}
+
+func TestLineChartFitsTerminal(t *testing.T) {
+	chart := NewLineChart().AddPoint("a", 0, 0).AddPoint("a", 10, 5)
+
+	out := &sizedBuffer{width: 40}
+	chart.Fprint(out)
+	if got := maxLineWidth(out.String()); got != 40 {
+		t.Errorf("chart width = %d, want 40:\n%s", got, out.String())
+	}
+
+	out = &sizedBuffer{width: 100}
+	chart.Fprint(out)
+	if got := maxLineWidth(out.String()); got != 60 {
+		t.Errorf("chart width = %d, want 60", got)
+	}
+
+	out = &sizedBuffer{width: 100}
+	chart.SetWidth(0).Fprint(out)
+	if got := maxLineWidth(out.String()); got != 100 {
+		t.Errorf("chart width = %d, want 100", got)
+	}
+}
+
+func TestProgressBarFitsTerminal(t *testing.T) {
+	if got := NewProgressBar(100).Width; got != 0 {
+		t.Errorf("default width = %d, want 0", got)
+	}
+
+	term := NewTerminal(&sizedBuffer{width: 40})
+	for _, width := range []int{0, 20, 200} {
+		bar := NewProgressBar(100).SetTerminal(term).SetWidth(width)
+		bar.Current = 50
+		line := bar.renderBar(term)
+		want := 39
+		if width == 20 {
+			want = StringWidth("[] 50.0% 50/100") + 20
+		}
+		if got := StringWidth(line); got != want {
+			t.Errorf("width %d: bar = %q (%d columns), want %d columns", width, line, got, want)
+		}
+	}
+}
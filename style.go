@@ -4,10 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strconv"
 	"strings"
-
-	"github.com/mattn/go-isatty"
 )
 
 // ANSI 颜色代码
@@ -18,6 +15,13 @@ const (
 	Italic    = "\033[3m" // 斜体
 	Underline = "\033[4m" // 下划线
 
+	Blink           = "\033[5m"   // 闪烁
+	Reverse         = "\033[7m"   // 反色
+	Strikethrough   = "\033[9m"   // 删除线
+	Overline        = "\033[53m"  // 上划线
+	DoubleUnderline = "\033[4:2m" // 双下划线（不支持时降级为普通下划线）
+	CurlyUnderline  = "\033[4:3m" // 波浪下划线（不支持时降级为普通下划线）
+
 	// 前景色（文本颜色）
 	FgBlack   = "\033[30m" // 黑色
 	FgRed     = "\033[31m" // 红色
@@ -41,20 +45,40 @@ const (
 
 // 全局配置
 var (
-	// 是否启用彩色输出：
-	// 1. 设置 NO_COLOR 环境变量
-	// 2. 终端为 dumb 终端
-	// 3. 不是终端
-	NoColor = os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" ||
-		(!isatty.IsTerminal(os.Stdout.Fd()) && !isatty.IsCygwinTerminal(os.Stdout.Fd()))
+	// 是否强制关闭所有彩色输出（默认根据 NO_COLOR 环境变量设置）。
+	// 每个输出目标是否为终端由其渲染器单独判断，参见 Renderer
+	NoColor = os.Getenv("NO_COLOR") != ""
 
 	// 默认输出目标
 	Output io.Writer = os.Stdout
 )
 
-// Style 表示一个带有样式的字符串
+// Style 表示一个带有样式的字符串。
+// Style 是不可变的：所有链式方法都返回新的样式，不会修改调用者，
+// 因此预设样式和组件样式可以在多个 goroutine 之间安全共享。
+// 空指针等同于不包含任何样式的空样式
 type Style struct {
-	codes []string
+	codes []styleCode
+	link  string // 超链接地址
+}
+
+// styleCode 表示一个样式代码，自适应颜色在浅色和深色背景下使用不同的代码
+type styleCode struct {
+	light string // 浅色背景下使用的代码
+	dark  string // 深色背景下使用的代码
+}
+
+// resolve 返回指定背景下使用的代码
+func (c styleCode) resolve(dark bool) string {
+	if dark {
+		return c.dark
+	}
+	return c.light
+}
+
+// adaptive 返回代码是否随背景变化
+func (c styleCode) adaptive() bool {
+	return c.light != c.dark
 }
 
 // New 创建一个新的样式
@@ -62,10 +86,66 @@ func New() *Style {
 	return &Style{}
 }
 
-// add 添加一个样式代码
+// codeList 返回样式代码列表，空样式返回 nil
+func (s *Style) codeList() []styleCode {
+	if s == nil {
+		return nil
+	}
+	return s.codes
+}
+
+// add 返回添加了一个样式代码的新样式
 func (s *Style) add(code string) *Style {
-	s.codes = append(s.codes, code)
-	return s
+	return s.addCode(styleCode{light: code, dark: code})
+}
+
+// addCode 返回添加了一个样式代码的新样式
+func (s *Style) addCode(code styleCode) *Style {
+	codes := make([]styleCode, 0, len(s.codeList())+1)
+	codes = append(codes, s.codeList()...)
+	return &Style{codes: append(codes, code), link: s.linkURL()}
+}
+
+// linkURL 返回样式的超链接地址，空样式返回空字符串
+func (s *Style) linkURL() string {
+	if s == nil {
+		return ""
+	}
+	return s.link
+}
+
+// Copy 返回样式的副本
+func (s *Style) Copy() *Style {
+	codes := make([]styleCode, len(s.codeList()))
+	copy(codes, s.codeList())
+	return &Style{codes: codes, link: s.linkURL()}
+}
+
+// Inherit 返回继承指定样式的新样式：先应用 parent 的样式代码，再应用当前样式的代码，
+// 因此当前样式中的设置会覆盖 parent 中的同类设置
+func (s *Style) Inherit(parent *Style) *Style {
+	if parent == nil {
+		return s.Copy()
+	}
+	codes := make([]styleCode, 0, len(parent.codes)+len(s.codeList()))
+	codes = append(codes, parent.codes...)
+	codes = append(codes, s.codeList()...)
+
+	link := s.linkURL()
+	if link == "" {
+		link = parent.link
+	}
+	return &Style{codes: codes, link: link}
+}
+
+// isAdaptive 返回样式中是否包含随背景变化的代码
+func (s *Style) isAdaptive() bool {
+	for _, code := range s.codeList() {
+		if code.adaptive() {
+			return true
+		}
+	}
+	return false
 }
 
 // 以下是各种样式方法
@@ -74,6 +154,13 @@ func (s *Style) Faint() *Style     { return s.add(Faint) }     // 弱化
 func (s *Style) Italic() *Style    { return s.add(Italic) }    // 斜体
 func (s *Style) Underline() *Style { return s.add(Underline) } // 下划线
 
+func (s *Style) Blink() *Style           { return s.add(Blink) }           // 闪烁
+func (s *Style) Reverse() *Style         { return s.add(Reverse) }         // 反色
+func (s *Style) Strikethrough() *Style   { return s.add(Strikethrough) }   // 删除线
+func (s *Style) Overline() *Style        { return s.add(Overline) }        // 上划线
+func (s *Style) DoubleUnderline() *Style { return s.add(DoubleUnderline) } // 双下划线
+func (s *Style) CurlyUnderline() *Style  { return s.add(CurlyUnderline) }  // 波浪下划线
+
 // Foreground colors
 func (s *Style) Black() *Style   { return s.add(FgBlack) }   // 黑色
 func (s *Style) Red() *Style     { return s.add(FgRed) }     // 红色
@@ -104,72 +191,124 @@ func (s *Style) BgRGB(r, g, b int) *Style {
 	return s.add(BgRGB(r, g, b))
 }
 
-// Sprint 返回带有样式的字符串
-func (s *Style) Sprint(a ...any) string {
-	if NoColor || len(s.codes) == 0 {
-		return fmt.Sprint(a...)
-	}
+// Fg 设置前景色
+func (s *Style) Fg(c Color) *Style {
+	return s.add(c.code("38"))
+}
 
-	// 应用所有样式代码
-	return strings.Join(s.codes, "") + fmt.Sprint(a...) + Reset
+// Bg 设置背景色
+func (s *Style) Bg(c Color) *Style {
+	return s.add(c.code("48"))
 }
 
-// Sprintf 返回带有样式和格式的字符串
-func (s *Style) Sprintf(format string, a ...any) string {
-	if NoColor || len(s.codes) == 0 {
-		return fmt.Sprintf(format, a...)
-	}
+// AdaptiveFg 设置随终端背景变化的前景色
+func (s *Style) AdaptiveFg(c AdaptiveColor) *Style {
+	return s.addCode(c.code("38"))
+}
+
+// AdaptiveBg 设置随终端背景变化的背景色
+func (s *Style) AdaptiveBg(c AdaptiveColor) *Style {
+	return s.addCode(c.code("48"))
+}
+
+// UnderlineColor 设置下划线颜色（不支持时会被忽略）
+func (s *Style) UnderlineColor(c Color) *Style {
+	return s.add(c.code("58"))
+}
 
-	return strings.Join(s.codes, "") + fmt.Sprintf(format, a...) + Reset
+// UnderlineRGB 设置下划线颜色（不支持时会被忽略）
+func (s *Style) UnderlineRGB(r, g, b int) *Style {
+	return s.add(UnderlineRGB(r, g, b))
+}
+
+// Link 返回链接到指定地址的新样式。
+// 支持 OSC 8 的终端中文本可以点击，不支持时输出为 "文本 (地址)"
+func (s *Style) Link(url string) *Style {
+	style := s.Copy()
+	style.link = url
+	return style
+}
+
+// Sprint 返回带有样式的字符串（使用默认渲染器的颜色能力）
+func (s *Style) Sprint(a ...any) string {
+	return DefaultRenderer().Sprint(s, a...)
+}
+
+// Sprintf 返回带有样式和格式的字符串（使用默认渲染器的颜色能力）
+func (s *Style) Sprintf(format string, a ...any) string {
+	return DefaultRenderer().Sprintf(s, format, a...)
 }
 
 // Print 输出带样式的文本
 func (s *Style) Print(a ...any) (n int, err error) {
-	return fmt.Fprint(Output, s.Sprint(a...))
+	return s.Fprint(Output, a...)
 }
 
 // Println 输出带样式的文本并换行
 func (s *Style) Println(a ...any) (n int, err error) {
-	return fmt.Fprintln(Output, s.Sprint(a...))
+	return s.Fprintln(Output, a...)
 }
 
 // Printf 输出带样式和格式的文本
 func (s *Style) Printf(format string, a ...any) (n int, err error) {
-	return fmt.Fprint(Output, s.Sprintf(format, a...))
+	return s.Fprintf(Output, format, a...)
 }
 
-// Fprint 输出带样式的文本到指定的writer
+// Fprint 输出带样式的文本到指定的writer（根据该 writer 决定颜色能力）
 func (s *Style) Fprint(w io.Writer, a ...any) (n int, err error) {
-	return fmt.Fprint(w, s.Sprint(a...))
+	return fmt.Fprint(w, rendererFor(w).Sprint(s, a...))
 }
 
 // Fprintln 输出带样式的文本到指定的 writer 并换行
 func (s *Style) Fprintln(w io.Writer, a ...any) (n int, err error) {
-	return fmt.Fprintln(w, s.Sprint(a...))
+	return fmt.Fprintln(w, rendererFor(w).Sprint(s, a...))
 }
 
 // Fprintf 输出带样式和格式的文本到指定的 writer
 func (s *Style) Fprintf(w io.Writer, format string, a ...any) (n int, err error) {
-	return fmt.Fprint(w, s.Sprintf(format, a...))
+	return fmt.Fprint(w, rendererFor(w).Sprintf(s, format, a...))
 }
 
-// RGB 创建自定义RGB颜色代码（仅支持真彩色终端）
+// render 按照指定的颜色能力和终端背景为文本应用样式，不支持的颜色会被降级为最接近的颜色
+func (s *Style) render(p ColorProfile, dark bool, text string) string {
+	if p == ProfileNoColor || len(s.codeList()) == 0 {
+		return text
+	}
+
+	var sb strings.Builder
+	for _, code := range s.codeList() {
+		sb.WriteString(p.Convert(code.resolve(dark)))
+	}
+	if sb.Len() == 0 {
+		return text
+	}
+
+	sb.WriteString(text)
+	sb.WriteString(Reset)
+	return sb.String()
+}
+
+// RGB 创建自定义RGB颜色代码，分量会被限制在 0-255 范围内（渲染时会根据终端的颜色能力自动降级）
 func RGB(r, g, b int) string {
-	return "\033[38;2;" + strconv.Itoa(r) + ";" + strconv.Itoa(g) + ";" + strconv.Itoa(b) + "m"
+	return rgbCode("38", r, g, b)
 }
 
-// BgRGB 创建自定义RGB背景色代码（仅支持真彩色终端）
+// BgRGB 创建自定义RGB背景色代码，分量会被限制在 0-255 范围内（渲染时会根据终端的颜色能力自动降级）
 func BgRGB(r, g, b int) string {
-	return "\033[48;2;" + strconv.Itoa(r) + ";" + strconv.Itoa(g) + ";" + strconv.Itoa(b) + "m"
+	return rgbCode("48", r, g, b)
+}
+
+// UnderlineRGB 创建自定义RGB下划线颜色代码（16 色终端不支持下划线颜色，渲染时会被忽略）
+func UnderlineRGB(r, g, b int) string {
+	return rgbCode("58", r, g, b)
 }
 
-// SetWriter 设置全局输出目标
+// SetWriter 设置全局输出目标，默认渲染器会根据新的输出目标重新检测颜色能力
 func SetWriter(w io.Writer) {
-	Output = w
+	SetDefaultRenderer(NewRenderer(w))
 }
 
-// Reset 重置所有样式
+// Reset 返回一个不包含任何样式的新样式
 func (s *Style) Reset() *Style {
-	s.codes = []string{}
-	return s
+	return New()
 }
@@ -2,6 +2,7 @@ package goterm
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -9,9 +10,10 @@ import (
 type Alignment int
 
 const (
-	AlignLeft   Alignment = iota // 左对齐
-	AlignCenter                  // 居中对齐
-	AlignRight                   // 右对齐
+	AlignLeft    Alignment = iota // 左对齐
+	AlignCenter                   // 居中对齐
+	AlignRight                    // 右对齐
+	AlignJustify                  // 两端对齐（用于文本换行，段落的最后一行左对齐；表格中按左对齐处理）
 )
 
 // TableColumn 表示表格的列
@@ -54,11 +56,12 @@ func (c *TableColumn) SetMaxWidth(width int) *TableColumn {
 type Table struct {
 	Columns         []TableColumn // 列定义
 	Rows            [][]string    // 行数据
-	Border          *Style        // 边框样式
-	Header          *Style        // 表头样式
+	Border          *Style        // 边框样式（为空时使用主题中的边框样式）
+	Header          *Style        // 表头样式（为空时使用主题中的表头样式）
 	Row             *Style        // 行样式
 	HasBorder       bool          // 是否显示边框
 	HasRowSeparator bool          // 是否显示行之间的分隔线
+	Theme           *Theme        // 主题（为空时使用全局主题）
 }
 
 // NewEmptyTable 创建一个没有列的新表格
@@ -66,9 +69,9 @@ func NewEmptyTable() *Table {
 	return &Table{
 		Columns:         make([]TableColumn, 0),
 		Rows:            make([][]string, 0),
-		Border:          New(),
-		Header:          New(),
-		Row:             New(),
+		Border:          nil,
+		Header:          nil,
+		Row:             nil,
 		HasBorder:       true,
 		HasRowSeparator: false,
 	}
@@ -92,27 +95,44 @@ func (t *Table) AddRow(cells ...string) {
 	t.Rows = append(t.Rows, row)
 }
 
-// 计算字符串的显示宽度，考虑全角字符（如中文）占用两个字符位置
-func displayWidth(s string) int {
-	width := 0
-	for _, r := range s {
-		if r > 0x7F { // ASCII范围外的字符（如中文）
-			width += 2
-		} else {
-			width += 1
+// escapeLength 返回字符串开头的 ANSI 转义序列长度，不是转义序列时返回 0
+func escapeLength(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+
+	switch s[1] {
+	case '[': // CSI 序列，以 0x40-0x7E 范围内的字符结束
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7E {
+				return i + 1
+			}
 		}
+		return len(s)
+	case ']': // OSC 序列，以 BEL 或 ESC \ 结束
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	default:
+		return 2
 	}
-	return width
 }
 
-// calculateColumnWidths 计算每列的实际宽度
-func (t *Table) calculateColumnWidths() []int {
+// calculateColumnWidths 计算每列的实际宽度。
+// maxWidth 大于 0 时表格的总宽度（包括边框和列间距）不超过 maxWidth：逐次缩短最宽的列，每列至少保留 3 列
+func (t *Table) calculateColumnWidths(maxWidth int) []int {
 	// 初始化为每列最小宽度
 	widths := make([]int, len(t.Columns))
 	for i, col := range t.Columns {
 		widths[i] = col.MinWidth
 		// 检查表头宽度
-		headerWidth := displayWidth(col.Header)
+		headerWidth := StringWidth(col.Header)
 		if headerWidth > widths[i] {
 			widths[i] = headerWidth
 		}
@@ -124,7 +144,7 @@ func (t *Table) calculateColumnWidths() []int {
 			if i >= len(widths) {
 				continue
 			}
-			cellWidth := displayWidth(cell)
+			cellWidth := StringWidth(cell)
 			if cellWidth > widths[i] && (t.Columns[i].MaxWidth == 0 || cellWidth <= t.Columns[i].MaxWidth) {
 				widths[i] = cellWidth
 			}
@@ -138,100 +158,149 @@ func (t *Table) calculateColumnWidths() []int {
 		}
 	}
 
+	if maxWidth > 0 {
+		shrinkColumns(widths, maxWidth-t.decorationWidth())
+	}
 	return widths
 }
 
-// formatCell 格式化单元格内容，根据对齐方式和宽度
-func formatCell(content string, width int, align Alignment) string {
-	// 计算内容的实际显示宽度（考虑中文字符占用2个宽度的情况）
-	displayWidth := 0
-	for _, r := range content {
-		if r > 0x7F { // ASCII范围外的字符（如中文）
-			displayWidth += 2
-		} else {
-			displayWidth += 1
+// decorationWidth 返回表格中边框和列间距占用的宽度
+func (t *Table) decorationWidth() int {
+	if t.HasBorder {
+		// 每列两侧各有一个空格，加上列之间和两侧的边框
+		return 3*len(t.Columns) + 1
+	}
+	return 2 * (len(t.Columns) - 1)
+}
+
+// shrinkColumns 逐次缩短最宽的列，直到各列宽度之和不超过 available 或者所有列都只剩 3 列
+func shrinkColumns(widths []int, available int) {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	for total > available {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
 		}
+		if widths[widest] <= 3 {
+			return
+		}
+		widths[widest]--
+		total--
 	}
+}
+
+// formatCell 格式化单元格内容，根据对齐方式和宽度
+func formatCell(content string, width int, align Alignment) string {
+	// 计算内容的实际显示宽度（忽略 ANSI 转义码，宽字符占用2个宽度）
+	contentWidth := StringWidth(content)
 
 	// 如果内容宽度超过列宽度，截断内容
-	if displayWidth > width {
-		// 安全截取UTF-8字符串
-		result := ""
-		currentWidth := 0
-
-		// 预留省略号的宽度（3个ASCII字符）
-		targetWidth := width - 3
-		if targetWidth < 0 {
-			targetWidth = 0
-		}
+	if contentWidth > width {
+		truncated, truncatedWidth := truncateText(content, width)
 
-		for _, r := range content {
-			charWidth := 1
-			if r > 0x7F {
-				charWidth = 2
-			}
+		// 根据对齐方式填充空格到指定宽度
+		return alignText(truncated, width-truncatedWidth, align)
+	}
 
-			// 如果添加这个字符会超出目标宽度，停止添加
-			if currentWidth+charWidth > targetWidth {
-				break
-			}
+	// 根据对齐方式填充空格
+	return alignText(content, width-contentWidth, align)
+}
 
-			result += string(r)
-			currentWidth += charWidth
+// truncateText 将文本截断到指定的显示宽度并添加省略号，返回截断后的文本及其显示宽度。
+// 文本中的转义码会被保留，截断后会重置样式并关闭超链接
+func truncateText(content string, width int) (string, int) {
+	// 安全截取UTF-8字符串
+	var result strings.Builder
+	currentWidth := 0
+	hasEscape := false
+	linkOpen := false
+
+	// 预留省略号的宽度（3个ASCII字符）
+	targetWidth := width - 3
+	if targetWidth < 0 {
+		targetWidth = 0
+	}
+
+	for i := 0; i < len(content); {
+		// 保留转义码（不占用显示宽度）
+		if n := escapeLength(content[i:]); n > 0 {
+			seq := content[i : i+n]
+			result.WriteString(seq)
+			if isLink, open := hyperlinkState(seq); isLink {
+				linkOpen = open
+			} else {
+				hasEscape = true
+			}
+			i += n
+			continue
 		}
 
-		// 添加省略号
-		result += "..."
-		currentWidth += 3
+		// 按字素簇截断，组合字符和表情符号序列不会被拆开
+		size, charWidth := nextGrapheme(content[i:])
 
-		// 根据对齐方式填充空格到指定宽度
-		remainingSpace := width - currentWidth
-		if remainingSpace > 0 {
-			switch align {
-			case AlignLeft:
-				return result + strings.Repeat(" ", remainingSpace)
-			case AlignRight:
-				return strings.Repeat(" ", remainingSpace) + result
-			case AlignCenter:
-				left := remainingSpace / 2
-				right := remainingSpace - left
-				return strings.Repeat(" ", left) + result + strings.Repeat(" ", right)
-			default:
-				return result + strings.Repeat(" ", remainingSpace)
-			}
+		// 如果添加这个字符会超出目标宽度，停止添加
+		if currentWidth+charWidth > targetWidth {
+			break
 		}
 
-		return result
+		result.WriteString(content[i : i+size])
+		currentWidth += charWidth
+		i += size
 	}
 
-	// 根据对齐方式填充空格
-	padding := width - displayWidth
+	// 截断后重置样式并关闭超链接，避免影响到后面的内容
+	if hasEscape {
+		result.WriteString(Reset)
+	}
+	if linkOpen {
+		result.WriteString(hyperlinkStart + hyperlinkEnd)
+	}
+
+	// 添加省略号
+	result.WriteString("...")
+	currentWidth += 3
+
+	return result.String(), currentWidth
+}
+
+// alignText 根据对齐方式使用 padding 个空格填充文本
+func alignText(text string, padding int, align Alignment) string {
 	if padding < 0 {
 		padding = 0
 	}
 
 	switch align {
 	case AlignLeft:
-		return content + strings.Repeat(" ", padding)
+		return text + strings.Repeat(" ", padding)
 	case AlignRight:
-		return strings.Repeat(" ", padding) + content
+		return strings.Repeat(" ", padding) + text
 	case AlignCenter:
 		left := padding / 2
 		right := padding - left
-		return strings.Repeat(" ", left) + content + strings.Repeat(" ", right)
+		return strings.Repeat(" ", left) + text + strings.Repeat(" ", right)
 	default:
-		return content + strings.Repeat(" ", padding)
+		return text + strings.Repeat(" ", padding)
 	}
 }
 
-// String 返回表格的字符串表示
+// String 返回表格的字符串表示（不限制宽度）
 func (t *Table) String() string {
+	return t.plainString(0)
+}
+
+// plainString 返回不带样式的表格字符串表示，maxWidth 大于 0 时限制表格的总宽度
+func (t *Table) plainString(maxWidth int) string {
 	if len(t.Columns) == 0 {
 		return ""
 	}
 
 	// 计算每列的宽度
-	widths := t.calculateColumnWidths()
+	widths := t.calculateColumnWidths(maxWidth)
 
 	var sb strings.Builder
 
@@ -333,198 +402,126 @@ func (t *Table) String() string {
 
 // Print 打印表格
 func (t *Table) Print() {
-	fmt.Print(t.String())
+	t.Fprint(Output)
+}
+
+// Fprint 打印表格到指定的 writer，输出到终端时表格的宽度不超过终端宽度
+func (t *Table) Fprint(w io.Writer) {
+	fmt.Fprint(w, t.plainString(outputWidth(rendererFor(w))))
 }
 
 // PrintWithStyle 使用样式打印表格
 func (t *Table) PrintWithStyle() {
-	// 如果没有样式，直接使用Print
-	if t.Border == nil && t.Header == nil && t.Row == nil {
-		t.Print()
-		return
-	}
-
-	if len(t.Columns) == 0 {
-		return
-	}
-
-	// 计算每列的宽度
-	widths := t.calculateColumnWidths()
+	t.FprintWithStyle(Output)
+}
 
-	// 绘制顶部边框
-	if t.HasBorder {
-		var borderLine strings.Builder
-		borderLine.WriteString("┌")
-		for i, width := range widths {
-			borderLine.WriteString(strings.Repeat("─", width+2))
-			if i < len(widths)-1 {
-				borderLine.WriteString("┬")
-			}
-		}
-		borderLine.WriteString("┐")
+// FprintWithStyle 使用样式打印表格到指定的 writer（根据该 writer 决定颜色能力），
+// 输出到终端时表格的宽度不超过终端宽度
+func (t *Table) FprintWithStyle(w io.Writer) {
+	r := rendererFor(w)
+	fmt.Fprint(w, t.styledString(r, outputWidth(r)))
+}
 
-		// 打印顶部边框
-		if t.Border != nil {
-			fmt.Println(t.Border.Sprint(borderLine.String()))
-		} else {
-			fmt.Println(borderLine.String())
-		}
-	}
+// StyledString 返回带有样式的表格字符串表示（使用默认渲染器的颜色能力，输出到终端时不超过终端宽度）
+func (t *Table) StyledString() string {
+	r := DefaultRenderer()
+	return t.styledString(r, outputWidth(r))
+}
 
-	// 绘制表头
-	if t.HasBorder {
-		if t.Border != nil {
-			fmt.Print(t.Border.Sprint("│"))
-		} else {
-			fmt.Print("│")
-		}
-		fmt.Print(" ")
+// styledString 使用指定的渲染器返回带有样式的表格字符串表示，maxWidth 大于 0 时限制表格的总宽度
+func (t *Table) styledString(r *Renderer, maxWidth int) string {
+	if len(t.Columns) == 0 {
+		return ""
 	}
 
-	// 打印表头内容
-	for i, col := range t.Columns {
-		cellContent := formatCell(col.Header, widths[i], AlignCenter)
-
-		// 应用表头样式
-		if t.Header != nil {
-			fmt.Print(t.Header.Sprint(cellContent))
-		} else {
-			fmt.Print(cellContent)
-		}
+	// 计算每列的宽度
+	widths := t.calculateColumnWidths(maxWidth)
 
-		if i < len(t.Columns)-1 {
-			if t.HasBorder {
-				if t.Border != nil {
-					fmt.Print(" " + t.Border.Sprint("│") + " ")
-				} else {
-					fmt.Print(" │ ")
-				}
-			} else {
-				fmt.Print("  ")
-			}
-		}
-	}
+	// 获取样式（表格自身的样式优先于主题）
+	theme := themeOr(t.Theme)
+	borderStyle := styleOr(t.Border, theme.Border)
+	headerStyle := styleOr(t.Header, theme.Header)
 
-	if t.HasBorder {
-		fmt.Print(" ")
-		if t.Border != nil {
-			fmt.Println(t.Border.Sprint("│"))
-		} else {
-			fmt.Println("│")
-		}
-	} else {
-		fmt.Println()
-	}
+	var sb strings.Builder
 
-	// 绘制表头与数据之间的分隔线
-	if t.HasBorder {
+	// 绘制水平边框线
+	writeBorderLine := func(left, middle, right string) {
 		var borderLine strings.Builder
-		borderLine.WriteString("├")
+		borderLine.WriteString(left)
 		for i, width := range widths {
 			borderLine.WriteString(strings.Repeat("─", width+2))
 			if i < len(widths)-1 {
-				borderLine.WriteString("┼")
+				borderLine.WriteString(middle)
 			}
 		}
-		borderLine.WriteString("┤")
-
-		// 打印分隔线
-		if t.Border != nil {
-			fmt.Println(t.Border.Sprint(borderLine.String()))
-		} else {
-			fmt.Println(borderLine.String())
-		}
+		borderLine.WriteString(right)
+		sb.WriteString(r.Sprint(borderStyle, borderLine.String()))
+		sb.WriteString("\n")
 	}
 
-	// 绘制数据行
-	for rowIndex, row := range t.Rows {
+	// 绘制一行单元格
+	writeCells := func(cells []string, style *Style, alignment func(i int) Alignment) {
 		if t.HasBorder {
-			if t.Border != nil {
-				fmt.Print(t.Border.Sprint("│"))
-			} else {
-				fmt.Print("│")
-			}
-			fmt.Print(" ")
+			sb.WriteString(r.Sprint(borderStyle, "│"))
+			sb.WriteString(" ")
 		}
 
-		for i, cell := range row {
+		for i, cell := range cells {
 			if i >= len(t.Columns) {
 				continue
 			}
 
-			cellContent := formatCell(cell, widths[i], t.Columns[i].Alignment)
-
-			// 应用行样式
-			if t.Row != nil {
-				fmt.Print(t.Row.Sprint(cellContent))
-			} else {
-				fmt.Print(cellContent)
-			}
+			sb.WriteString(r.Sprint(style, formatCell(cell, widths[i], alignment(i))))
 
 			if i < len(t.Columns)-1 {
 				if t.HasBorder {
-					if t.Border != nil {
-						fmt.Print(" " + t.Border.Sprint("│") + " ")
-					} else {
-						fmt.Print(" │ ")
-					}
+					sb.WriteString(" " + r.Sprint(borderStyle, "│") + " ")
 				} else {
-					fmt.Print("  ")
+					sb.WriteString("  ")
 				}
 			}
 		}
 
 		if t.HasBorder {
-			fmt.Print(" ")
-			if t.Border != nil {
-				fmt.Println(t.Border.Sprint("│"))
-			} else {
-				fmt.Println("│")
-			}
-		} else {
-			fmt.Println()
+			sb.WriteString(" ")
+			sb.WriteString(r.Sprint(borderStyle, "│"))
 		}
+		sb.WriteString("\n")
+	}
+
+	// 绘制顶部边框
+	if t.HasBorder {
+		writeBorderLine("┌", "┬", "┐")
+	}
+
+	// 绘制表头
+	headers := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		headers[i] = col.Header
+	}
+	writeCells(headers, headerStyle, func(int) Alignment { return AlignCenter })
+
+	// 绘制表头与数据之间的分隔线
+	if t.HasBorder {
+		writeBorderLine("├", "┼", "┤")
+	}
+
+	// 绘制数据行
+	for rowIndex, row := range t.Rows {
+		writeCells(row, t.Row, func(i int) Alignment { return t.Columns[i].Alignment })
 
 		// 添加行之间的分隔线（除了最后一行之后）
 		if t.HasRowSeparator && t.HasBorder && rowIndex < len(t.Rows)-1 {
-			var borderLine strings.Builder
-			borderLine.WriteString("├")
-			for i, width := range widths {
-				borderLine.WriteString(strings.Repeat("─", width+2))
-				if i < len(widths)-1 {
-					borderLine.WriteString("┼")
-				}
-			}
-			borderLine.WriteString("┤")
-
-			// 打印行分隔线
-			if t.Border != nil {
-				fmt.Println(t.Border.Sprint(borderLine.String()))
-			} else {
-				fmt.Println(borderLine.String())
-			}
+			writeBorderLine("├", "┼", "┤")
 		}
 	}
 
 	// 绘制底部边框
 	if t.HasBorder {
-		var borderLine strings.Builder
-		borderLine.WriteString("└")
-		for i, width := range widths {
-			borderLine.WriteString(strings.Repeat("─", width+2))
-			if i < len(widths)-1 {
-				borderLine.WriteString("┴")
-			}
-		}
-		borderLine.WriteString("┘")
-
-		// 打印底部边框
-		if t.Border != nil {
-			fmt.Println(t.Border.Sprint(borderLine.String()))
-		} else {
-			fmt.Println(borderLine.String())
-		}
+		writeBorderLine("└", "┴", "┘")
 	}
+
+	return sb.String()
 }
 
 // PrintStyled 使用提供的样式打印表格
@@ -550,10 +547,8 @@ func (t *Table) PrintStyled(borderStyle, headerStyle, rowStyle *Style) {
 
 // PrintStyledTable 以更灵活的方式打印带样式的表格
 func PrintStyledTable(table *Table, title string, titleStyle, borderStyle *Style) {
-	if titleStyle != nil && title != "" {
-		fmt.Println(titleStyle.Sprint(title))
-	} else if title != "" {
-		fmt.Println(title)
+	if title != "" {
+		titleStyle.Println(title)
 	}
 
 	if borderStyle != nil {
@@ -593,7 +588,13 @@ func (t *Table) SetHasRowSeparator(hasRowSeparator bool) *Table {
 	return t
 }
 
-// ResetStyles 重置所有样式设置
+// SetTheme 设置表格使用的主题（为空时使用全局主题）
+func (t *Table) SetTheme(theme *Theme) *Table {
+	t.Theme = theme
+	return t
+}
+
+// ResetStyles 重置所有样式设置（重置后使用主题中的样式）
 func (t *Table) ResetStyles() *Table {
 	t.Border = nil
 	t.Header = nil
@@ -623,6 +624,7 @@ func (t *Table) Clone() *Table {
 		Row:             t.Row,
 		HasBorder:       t.HasBorder,
 		HasRowSeparator: t.HasRowSeparator,
+		Theme:           t.Theme,
 	}
 
 	// 复制列定义
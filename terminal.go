@@ -0,0 +1,206 @@
+package goterm
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Terminal 表示绑定到具体输出目标（以及可选的输入源）的终端，
+// 负责光标控制、清除、样式渲染和原始模式。
+// 光标、进度条、动画和交互式组件都通过 Terminal 输出，
+// 因此一个程序可以同时驱动多个输出目标，测试时也可以把输出写入缓冲区
+type Terminal struct {
+	renderer *Renderer // 渲染器（同时决定输出目标）
+	in       io.Reader // 输入源
+
+	syncOnce   sync.Once // 是否支持同步输出只在第一次使用时根据环境变量判断
+	syncOutput bool      // 终端是否支持同步输出（模式 2026）
+}
+
+// NewTerminal 创建一个输出到指定 writer 的终端，输入源默认为标准输入
+func NewTerminal(w io.Writer) *Terminal {
+	return &Terminal{
+		renderer: rendererFor(w),
+		in:       os.Stdin,
+	}
+}
+
+// 默认终端，输出到全局输出目标 Output
+var (
+	defaultTerminal   *Terminal
+	defaultTerminalMu sync.Mutex
+)
+
+// DefaultTerminal 返回输出到全局输出目标 Output、从标准输入读取的终端
+func DefaultTerminal() *Terminal {
+	r := DefaultRenderer()
+
+	defaultTerminalMu.Lock()
+	defer defaultTerminalMu.Unlock()
+
+	if defaultTerminal == nil || defaultTerminal.renderer != r {
+		defaultTerminal = &Terminal{renderer: r, in: os.Stdin}
+	}
+	return defaultTerminal
+}
+
+// terminalOr 返回组件使用的终端：组件单独设置了终端时使用该终端，否则使用默认终端
+func terminalOr(t *Terminal) *Terminal {
+	if t != nil {
+		return t
+	}
+	return DefaultTerminal()
+}
+
+// SetInput 设置终端的输入源
+func (t *Terminal) SetInput(in io.Reader) *Terminal {
+	t.in = in
+	return t
+}
+
+// Writer 返回终端的输出目标
+func (t *Terminal) Writer() io.Writer {
+	return t.renderer.Writer()
+}
+
+// Input 返回终端的输入源
+func (t *Terminal) Input() io.Reader {
+	return t.in
+}
+
+// Renderer 返回终端使用的渲染器
+func (t *Terminal) Renderer() *Renderer {
+	return t.renderer
+}
+
+// IsTerminal 返回终端的输出目标是否为真实的终端设备
+func (t *Terminal) IsTerminal() bool {
+	return t.renderer.isTerminal()
+}
+
+// Write 实现 io.Writer 接口，内容中的样式代码会被转换为输出目标支持的颜色能力
+func (t *Terminal) Write(p []byte) (n int, err error) {
+	return t.renderer.Write(p)
+}
+
+// Print 输出文本
+func (t *Terminal) Print(a ...any) (n int, err error) {
+	return fmt.Fprint(t, a...)
+}
+
+// Println 输出文本并换行
+func (t *Terminal) Println(a ...any) (n int, err error) {
+	return fmt.Fprintln(t, a...)
+}
+
+// Printf 输出格式化文本
+func (t *Terminal) Printf(format string, a ...any) (n int, err error) {
+	return fmt.Fprintf(t, format, a...)
+}
+
+// Sprint 返回按照终端颜色能力使用指定样式渲染的字符串
+func (t *Terminal) Sprint(style *Style, a ...any) string {
+	return t.renderer.Sprint(style, a...)
+}
+
+// Sprintf 返回按照终端颜色能力使用指定样式和格式渲染的字符串
+func (t *Terminal) Sprintf(style *Style, format string, a ...any) string {
+	return t.renderer.Sprintf(style, format, a...)
+}
+
+// Cursor 返回控制该终端光标的 Cursor
+func (t *Terminal) Cursor() *Cursor {
+	return &Cursor{term: t}
+}
+
+// MoveUp 将光标向上移动n行
+func (t *Terminal) MoveUp(n int) {
+	t.Printf("\033[%dA", n)
+}
+
+// MoveDown 将光标向下移动n行
+func (t *Terminal) MoveDown(n int) {
+	t.Printf("\033[%dB", n)
+}
+
+// MoveRight 将光标向右移动n列
+func (t *Terminal) MoveRight(n int) {
+	t.Printf("\033[%dC", n)
+}
+
+// MoveLeft 将光标向左移动n列
+func (t *Terminal) MoveLeft(n int) {
+	t.Printf("\033[%dD", n)
+}
+
+// MoveTo 将光标移动到指定位置
+func (t *Terminal) MoveTo(row, col int) {
+	t.Printf("\033[%d;%dH", row, col)
+}
+
+// MoveToColumn 将光标移动到当前行的指定列（从 1 开始）
+func (t *Terminal) MoveToColumn(col int) {
+	t.Printf("\033[%dG", col)
+}
+
+// MoveToNextLine 将光标向下移动n行并移动到行首
+func (t *Terminal) MoveToNextLine(n int) {
+	t.Printf("\033[%dE", n)
+}
+
+// MoveToPrevLine 将光标向上移动n行并移动到行首
+func (t *Terminal) MoveToPrevLine(n int) {
+	t.Printf("\033[%dF", n)
+}
+
+// SavePosition 保存当前光标位置
+func (t *Terminal) SavePosition() {
+	t.Print("\033[s")
+}
+
+// RestorePosition 恢复之前保存的光标位置
+func (t *Terminal) RestorePosition() {
+	t.Print("\033[u")
+}
+
+// ClearScreen 清除整个屏幕
+func (t *Terminal) ClearScreen() {
+	t.Print("\033[2J")
+}
+
+// ClearLine 清除从光标位置到行尾的内容
+func (t *Terminal) ClearLine() {
+	t.Print("\033[K")
+}
+
+// ClearLineStart 清除从行首到光标位置的内容
+func (t *Terminal) ClearLineStart() {
+	t.Print("\033[1K")
+}
+
+// ClearEntireLine 清除光标所在的整行
+func (t *Terminal) ClearEntireLine() {
+	t.Print("\033[2K")
+}
+
+// ClearScreenDown 清除从光标位置到屏幕底部的内容
+func (t *Terminal) ClearScreenDown() {
+	t.Print("\033[J")
+}
+
+// ClearScreenUp 清除从屏幕顶部到光标位置的内容
+func (t *Terminal) ClearScreenUp() {
+	t.Print("\033[1J")
+}
+
+// HideCursor 隐藏光标
+func (t *Terminal) HideCursor() {
+	t.Print("\033[?25l")
+}
+
+// ShowCursor 显示光标
+func (t *Terminal) ShowCursor() {
+	t.Print("\033[?25h")
+}
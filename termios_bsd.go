@@ -0,0 +1,11 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package goterm
+
+import "golang.org/x/sys/unix"
+
+// 读取和设置终端属性的 ioctl 请求
+const (
+	ioctlGetTermios = unix.TIOCGETA
+	ioctlSetTermios = unix.TIOCSETA
+)
@@ -0,0 +1,9 @@
+package goterm
+
+import "golang.org/x/sys/unix"
+
+// 读取和设置终端属性的 ioctl 请求
+const (
+	ioctlGetTermios = unix.TCGETS
+	ioctlSetTermios = unix.TCSETS
+)
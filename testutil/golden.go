@@ -0,0 +1,113 @@
+package testutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// UpdateEnv 设置为 1 时 AssertGolden 重新生成 golden 文件，例如 GOTERM_UPDATE_GOLDEN=1 go test ./...
+const UpdateEnv = "GOTERM_UPDATE_GOLDEN"
+
+// Update 为 true 时 AssertGolden 重新生成 golden 文件。
+// 测试可以把自己定义的 -update 标志赋值给它，例如在 TestMain 中 testutil.Update = *update
+var Update bool
+
+// GoldenDir golden 文件所在的目录（相对于测试所在的包）
+var GoldenDir = "testdata"
+
+// updating 返回是否需要重新生成 golden 文件
+func updating() bool {
+	return Update || os.Getenv(UpdateEnv) == "1"
+}
+
+// AssertGolden 将 got 与 golden 文件 GoldenDir/name.golden 比较，不一致时报告差异。
+// 设置了 Update 或环境变量 GOTERM_UPDATE_GOLDEN=1 时把 got 写入 golden 文件
+func AssertGolden(t testing.TB, name string, got string) {
+	t.Helper()
+
+	path := filepath.Join(GoldenDir, name+".golden")
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file: %v (run with GOTERM_UPDATE_GOLDEN=1 to create it)", err)
+	}
+	if string(want) != got {
+		t.Errorf("output does not match %s (run with GOTERM_UPDATE_GOLDEN=1 to accept it):\n%s", path, Diff(string(want), got))
+	}
+}
+
+// Visible 将文本中的转义字符和控制字符替换为可见的符号，例如 ESC 显示为 ␛，换行和制表符保持不变
+func Visible(s string) string {
+	var out strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\n' || r == '\t':
+			out.WriteRune(r)
+		case r == 0x1B:
+			out.WriteString("␛")
+		case r == '\r':
+			out.WriteString("␍")
+		case r == '\a':
+			out.WriteString("␇")
+		case r < 0x20 || r == 0x7F:
+			fmt.Fprintf(&out, "\\x%02x", r)
+		default:
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
+
+// Diff 返回两段文本逐行比较的差异：删除的行以 "- " 开头，新增的行以 "+ " 开头，
+// 相同的行以两个空格开头，所有转义序列都显示为可见的符号
+func Diff(want, got string) string {
+	a, b := strings.Split(want, "\n"), strings.Split(got, "\n")
+
+	// lcs[i][j] 为 a[i:] 和 b[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out.WriteString("  " + Visible(a[i]) + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			// 同一处的修改先输出删除的行，再输出添加的行
+			out.WriteString("- " + Visible(a[i]) + "\n")
+			i++
+		default:
+			out.WriteString("+ " + Visible(b[j]) + "\n")
+			j++
+		}
+	}
+	return out.String()
+}
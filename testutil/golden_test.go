@@ -0,0 +1,165 @@
+package testutil_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/lllllan02/goterm"
+	"github.com/lllllan02/goterm/testutil"
+)
+
+// 测试包定义自己的 -update 标志不会与 testutil 冲突
+var update = flag.Bool("update", false, "update golden files")
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	testutil.Update = *update
+	os.Exit(m.Run())
+}
+
+// recorder 记录 AssertGolden 报告的错误
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+	r.fatal = true
+	runtime.Goexit()
+}
+
+// assertGolden 在单独的 goroutine 中调用 AssertGolden，使 Fatalf 可以结束调用
+func assertGolden(t *testing.T, dir, name, got string) *recorder {
+	t.Helper()
+	saved := testutil.GoldenDir
+	testutil.GoldenDir = dir
+	defer func() { testutil.GoldenDir = saved }()
+
+	r := &recorder{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		testutil.AssertGolden(r, name, got)
+	}()
+	<-done
+	return r
+}
+
+func TestAssertGolden(t *testing.T) {
+	dir := t.TempDir()
+
+	// golden 文件不存在
+	if r := assertGolden(t, dir, "missing", "x"); !r.fatal || !strings.Contains(r.errors[0], "GOTERM_UPDATE_GOLDEN=1") {
+		t.Errorf("missing golden file: %q", r.errors)
+	}
+
+	// 重新生成 golden 文件
+	t.Setenv(testutil.UpdateEnv, "1")
+	if r := assertGolden(t, dir, "nested/out", "a\n\x1b[1mb\x1b[0m"); len(r.errors) > 0 {
+		t.Fatalf("update: %q", r.errors)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "nested", "out.golden"))
+	if err != nil || string(data) != "a\n\x1b[1mb\x1b[0m" {
+		t.Fatalf("golden file = %q, %v", data, err)
+	}
+	t.Setenv(testutil.UpdateEnv, "")
+
+	// 内容相同
+	if r := assertGolden(t, dir, "nested/out", "a\n\x1b[1mb\x1b[0m"); len(r.errors) > 0 {
+		t.Errorf("matching output: %q", r.errors)
+	}
+
+	// 内容不同时报告可见的差异
+	r := assertGolden(t, dir, "nested/out", "a\n\x1b[2mb\x1b[0m")
+	if r.fatal || len(r.errors) != 1 {
+		t.Fatalf("mismatch: fatal = %v, errors = %q", r.fatal, r.errors)
+	}
+	if !strings.Contains(r.errors[0], "- ␛[1mb␛[0m\n+ ␛[2mb␛[0m") {
+		t.Errorf("mismatch report:\n%s", r.errors[0])
+	}
+}
+
+func TestVisible(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"\x1b[1mbold\x1b[0m", "␛[1mbold␛[0m"},
+		{"a\r\nb\tc", "a␍\nb\tc"},
+		{"\x1b]8;;url\x07", "␛]8;;url␇"},
+		{"\x00\x7f", "\\x00\\x7f"},
+		{"中文", "中文"},
+	}
+	for _, tt := range tests {
+		if got := testutil.Visible(tt.in); got != tt.want {
+			t.Errorf("Visible(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name, want, got, diff string
+	}{
+		{"equal", "a\nb", "a\nb", "  a\n  b\n"},
+		{"changed line", "a\nb\nc", "a\nx\nc", "  a\n- b\n+ x\n  c\n"},
+		{"added line", "a\nc", "a\nb\nc", "  a\n+ b\n  c\n"},
+		{"removed line", "a\nb\nc", "a\nc", "  a\n- b\n  c\n"},
+		{"escapes", "\x1b[1ma", "\x1b[2ma", "- ␛[1ma\n+ ␛[2ma\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testutil.Diff(tt.want, tt.got); got != tt.diff {
+				t.Errorf("Diff = %q, want %q", got, tt.diff)
+			}
+		})
+	}
+}
+
+// TestSalesChart 是 README 中的示例
+func TestSalesChart(t *testing.T) {
+	chart := goterm.NewBarChart().
+		AddData("一月", 120).
+		AddData("二月", 80)
+
+	testutil.AssertGolden(t, "sales", testutil.Render(chart))
+	testutil.AssertGolden(t, "sales_ansi", testutil.RenderANSI(chart))
+
+	r := testutil.NewRenderer().
+		SetWidth(40).
+		SetColorProfile(goterm.ProfileANSI256).
+		SetTheme(goterm.HighContrastTheme)
+	testutil.AssertGolden(t, "sales_256", r.Render(chart))
+
+	screen := r.Screen(func(term *goterm.Terminal) {
+		bar := goterm.NewProgressBar(100).SetTerminal(term)
+		bar.Set(50)
+		bar.Finish()
+	})
+	testutil.AssertGolden(t, "progress", screen)
+}
+
+// TestTable 检查表格在固定设置下的输出
+func TestTable(t *testing.T) {
+	table := goterm.NewEmptyTable()
+	table.AddColumn(goterm.NewColumn("名称"))
+	table.AddColumn(goterm.NewColumn("数量").SetAlignment(goterm.AlignRight))
+	table.AddRow("苹果", "3")
+	table.AddRow("banana", "12")
+
+	testutil.AssertGolden(t, "table", testutil.Render(table))
+	testutil.AssertGolden(t, "table_ansi", testutil.RenderANSI(table))
+}
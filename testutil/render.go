@@ -0,0 +1,147 @@
+// Package testutil 提供测试 goterm 组件的辅助函数：
+// 使用确定的设置（固定宽度、固定时间、固定颜色能力和主题）渲染组件，
+// 并与 golden 文件比较，不一致时输出转义序列可见的差异
+package testutil
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/lllllan02/goterm"
+	"github.com/lllllan02/goterm/vt"
+)
+
+// Component 表示可以输出到 writer 的组件，例如 Table、Tree、BarChart、LineChart 和 PieChart
+type Component interface {
+	Fprint(w io.Writer)
+}
+
+// DefaultClock 渲染时使用的默认固定时间
+var DefaultClock = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// Renderer 使用确定的设置渲染组件，同一组件在任何环境下的输出都相同
+type Renderer struct {
+	width      int                 // 终端宽度
+	height     int                 // 终端高度
+	profile    goterm.ColorProfile // 颜色能力
+	clock      time.Time           // 固定时间
+	theme      *goterm.Theme       // 主题
+	hyperlinks bool                // 是否输出超链接
+}
+
+// NewRenderer 创建一个渲染器：80 列 24 行、不输出颜色、默认主题、时间固定为 DefaultClock
+func NewRenderer() *Renderer {
+	return &Renderer{
+		width:   80,
+		height:  24,
+		profile: goterm.ProfileNoColor,
+		clock:   DefaultClock,
+		theme:   goterm.DefaultTheme,
+	}
+}
+
+// SetWidth 设置终端宽度
+func (r *Renderer) SetWidth(width int) *Renderer {
+	r.width = width
+	return r
+}
+
+// SetHeight 设置终端高度
+func (r *Renderer) SetHeight(height int) *Renderer {
+	r.height = height
+	return r
+}
+
+// SetColorProfile 设置颜色能力，ProfileNoColor 时输出纯文本
+func (r *Renderer) SetColorProfile(profile goterm.ColorProfile) *Renderer {
+	r.profile = profile
+	return r
+}
+
+// SetClock 设置渲染时 goterm.Now 返回的固定时间
+func (r *Renderer) SetClock(clock time.Time) *Renderer {
+	r.clock = clock
+	return r
+}
+
+// SetTheme 设置渲染时使用的全局主题
+func (r *Renderer) SetTheme(theme *goterm.Theme) *Renderer {
+	r.theme = theme
+	return r
+}
+
+// SetHyperlinks 设置是否输出 OSC 8 超链接
+func (r *Renderer) SetHyperlinks(enabled bool) *Renderer {
+	r.hyperlinks = enabled
+	return r
+}
+
+// Render 渲染组件，返回包含转义序列的输出
+func (r *Renderer) Render(c Component) string {
+	return r.RenderFunc(func(term *goterm.Terminal) {
+		c.Fprint(term.Renderer())
+	})
+}
+
+// RenderFunc 执行 fn 并返回它输出到终端的内容（包含转义序列），
+// 适用于进度条、选择框等需要终端的组件
+func (r *Renderer) RenderFunc(fn func(term *goterm.Terminal)) string {
+	out := &sizedBuffer{width: r.width, height: r.height}
+	r.run(out, fn)
+	return out.String()
+}
+
+// Screen 执行 fn，把输出写入虚拟终端，返回最终的屏幕内容（纯文本）。
+// 光标移动和重绘都会被执行，因此只包含用户最终看到的画面
+func (r *Renderer) Screen(fn func(term *goterm.Terminal)) string {
+	emulator := vt.NewEmulator(r.width, r.height)
+	r.run(emulator, fn)
+	return emulator.String()
+}
+
+// 渲染时会替换全局主题和时间，同一时间只能有一个渲染在进行
+var renderMu sync.Mutex
+
+// run 使用确定的设置执行 fn，结束后恢复全局主题和时间
+func (r *Renderer) run(w io.Writer, fn func(term *goterm.Terminal)) {
+	renderMu.Lock()
+	defer renderMu.Unlock()
+
+	theme, now := goterm.CurrentTheme(), goterm.Now
+	goterm.SetTheme(r.theme)
+	clock := r.clock
+	goterm.Now = func() time.Time { return clock }
+	defer func() {
+		goterm.SetTheme(theme)
+		goterm.Now = now
+	}()
+
+	renderer := goterm.NewRenderer(w).
+		SetColorProfile(r.profile).
+		SetHyperlinks(r.hyperlinks).
+		SetHasDarkBackground(true)
+	fn(goterm.NewTerminal(renderer).SetInput(bytes.NewReader(nil)))
+}
+
+// Render 使用默认设置渲染组件，返回不包含颜色的纯文本
+func Render(c Component) string {
+	return NewRenderer().Render(c)
+}
+
+// RenderANSI 使用默认设置和真彩色渲染组件，返回包含样式转义序列的文本
+func RenderANSI(c Component) string {
+	return NewRenderer().SetColorProfile(goterm.ProfileTrueColor).Render(c)
+}
+
+// sizedBuffer 是报告固定尺寸的缓冲区，输出到它的终端使用该尺寸布局
+type sizedBuffer struct {
+	bytes.Buffer
+	width, height int
+}
+
+// Size 返回缓冲区的尺寸
+func (b *sizedBuffer) Size() (columns, rows int) {
+	return b.width, b.height
+}
@@ -1,13 +1,16 @@
 package goterm
 
 import (
-	"fmt"
 	"strings"
 )
 
 // TextFormatter 提供文本格式化功能
 type TextFormatter struct {
-	style *Style
+	style     *Style
+	width     int       // 换行宽度（包括缩进），为 0 时不换行
+	align     Alignment // 换行后每行的对齐方式
+	hanging   int       // 悬挂缩进：段落第二行及以后相对第一行额外缩进的空格数
+	wordBreak WordBreak // 超过行宽的单词（例如 URL）的处理方式
 }
 
 // NewTextFormatter 创建一个新的文本格式化器
@@ -17,12 +20,50 @@ func NewTextFormatter() *TextFormatter {
 	}
 }
 
+// SetWidth 设置换行宽度（按显示宽度计算，包括缩进），为 0 时不换行
+func (tf *TextFormatter) SetWidth(width int) *TextFormatter {
+	tf.width = width
+	return tf
+}
+
+// SetAlignment 设置换行后每行的对齐方式：左对齐、右对齐、居中或两端对齐
+func (tf *TextFormatter) SetAlignment(align Alignment) *TextFormatter {
+	tf.align = align
+	return tf
+}
+
+// SetHangingIndent 设置悬挂缩进，段落换行后的各行比第一行多缩进 indent 个空格
+func (tf *TextFormatter) SetHangingIndent(indent int) *TextFormatter {
+	tf.hanging = indent
+	return tf
+}
+
+// SetWordBreak 设置超过行宽的单词的处理方式，默认不拆分
+func (tf *TextFormatter) SetWordBreak(wordBreak WordBreak) *TextFormatter {
+	tf.wordBreak = wordBreak
+	return tf
+}
+
+// wrap 使用格式化器的设置换行，first 和 rest 分别为段落第一行和其余各行的前缀
+func (tf *TextFormatter) wrap(text, first, rest string) []string {
+	return wrapText(text, wrapOptions{
+		width:     tf.width,
+		first:     first,
+		rest:      rest,
+		align:     tf.align,
+		wordBreak: tf.wordBreak,
+	})
+}
+
 // Paragraph 格式化段落
-// text: 段落文本
+// text: 段落文本（设置了宽度时按宽度换行，样式跨行时会在下一行重新开启）
 // indent: 缩进空格数
 func (tf *TextFormatter) Paragraph(text string, indent int) string {
 	// 添加缩进
 	indentStr := strings.Repeat(" ", indent)
+	if tf.width > 0 {
+		return strings.Join(tf.wrap(text, indentStr, indentStr+strings.Repeat(" ", tf.hanging)), "\n")
+	}
 
 	// 处理换行，确保每行都有正确的缩进
 	lines := strings.Split(text, "\n")
@@ -35,7 +76,7 @@ func (tf *TextFormatter) Paragraph(text string, indent int) string {
 	return strings.Join(lines, "\n")
 }
 
-// List 格式化列表
+// List 格式化列表，多行的列表项与列表符号后的文本对齐
 // items: 列表项
 // bullet: 列表符号（默认为"•"）
 // indent: 缩进空格数
@@ -44,31 +85,42 @@ func (tf *TextFormatter) List(items []string, bullet string, indent int) string
 		bullet = "•"
 	}
 
-	// 添加缩进
-	indentStr := strings.Repeat(" ", indent)
-
-	// 格式化每个列表项
-	formattedItems := make([]string, len(items))
-	for i, item := range items {
-		formattedItems[i] = fmt.Sprintf("%s%s %s", indentStr, bullet, item)
+	list := NewList(ListBullet).SetBullets(bullet)
+	for _, item := range items {
+		list.Add(item)
 	}
-
-	return strings.Join(formattedItems, "\n")
+	return tf.FormatList(list, indent)
 }
 
-// NumberedList 格式化有序列表
+// NumberedList 格式化有序列表，编号右对齐，多行的列表项与编号后的文本对齐
 // items: 列表项
 // start: 起始编号
 // indent: 缩进空格数
 func (tf *TextFormatter) NumberedList(items []string, start int, indent int) string {
-	// 添加缩进
-	indentStr := strings.Repeat(" ", indent)
-
-	// 格式化每个列表项
-	formattedItems := make([]string, len(items))
-	for i, item := range items {
-		formattedItems[i] = fmt.Sprintf("%s%d. %s", indentStr, start+i, item)
+	list := NewList(ListNumbered).SetStart(start)
+	for _, item := range items {
+		list.Add(item)
 	}
+	return tf.FormatList(list, indent)
+}
 
-	return strings.Join(formattedItems, "\n")
+// hang 格式化悬挂的文本：第一行以 first 开头，其余各行（包括换行产生的行）以 rest 开头。
+// 设置了宽度时按宽度换行
+func (tf *TextFormatter) hang(text, first, rest string) []string {
+	var lines []string
+	for i, paragraph := range strings.Split(text, "\n") {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		switch {
+		case tf.width > 0:
+			lines = append(lines, tf.wrap(paragraph, prefix, rest)...)
+		case paragraph == "" && i > 0:
+			lines = append(lines, "")
+		default:
+			lines = append(lines, prefix+paragraph)
+		}
+	}
+	return lines
 }
@@ -0,0 +1,188 @@
+package goterm
+
+import "sync"
+
+// Theme 表示一组语义化的样式角色，组件在渲染时从主题中获取默认样式。
+// 组件自身设置的样式优先于主题，组件也可以通过 SetTheme 使用独立的主题
+type Theme struct {
+	Error     *Style   // 错误（日志级别、取消提示）
+	Success   *Style   // 成功
+	Warning   *Style   // 警告
+	Info      *Style   // 信息
+	Remark    *Style   // 备注
+	Muted     *Style   // 弱化文本（网格线、操作提示）
+	Accent    *Style   // 强调色（条形图的条形）
+	Prompt    *Style   // 交互组件的提示文本
+	Selection *Style   // 交互组件的选中项
+	Border    *Style   // 边框和坐标轴
+	Header    *Style   // 表头
+	Title     *Style   // 图表标题
+	Value     *Style   // 图表中的数值
+	Progress  *Style   // 进度条
+	Palette   []*Style // 图表系列调色板
+}
+
+// 内置主题
+var (
+	// DefaultTheme 默认主题
+	DefaultTheme = &Theme{
+		Error:     New().Bold().Red(),
+		Success:   New().Bold().RGB(0, 128, 0),
+		Warning:   New().Bold().AdaptiveFg(AdaptiveColor{Light: MustHex("#8a5a00"), Dark: ANSIColor(3)}),
+		Info:      New().Bold().Blue(),
+		Remark:    New().Bold().Cyan(),
+		Muted:     New().Faint(),
+		Accent:    New().Cyan(),
+		Prompt:    New().Bold().Blue(),
+		Selection: New().Green(),
+		Border:    New(),
+		Header:    New(),
+		Title:     New().Bold().Underline(),
+		Value:     New().Bold(),
+		Progress:  New(),
+		Palette: []*Style{
+			New().Red(),
+			New().Green(),
+			New().Yellow(),
+			New().Blue(),
+			New().Magenta(),
+			New().Cyan(),
+			New().White(),
+		},
+	}
+
+	// HighContrastTheme 高对比度主题，使用明亮的颜色和粗体
+	HighContrastTheme = &Theme{
+		Error:     New().Bold().Fg(ANSIColor(9)),
+		Success:   New().Bold().Fg(ANSIColor(10)),
+		Warning:   New().Bold().Fg(ANSIColor(11)),
+		Info:      New().Bold().Fg(ANSIColor(14)),
+		Remark:    New().Bold().Fg(ANSIColor(13)),
+		Muted:     New().Fg(ANSIColor(7)),
+		Accent:    New().Bold().Fg(ANSIColor(14)),
+		Prompt:    New().Bold().Fg(ANSIColor(15)),
+		Selection: New().Bold().Reverse(),
+		Border:    New().Bold().Fg(ANSIColor(15)),
+		Header:    New().Bold().Underline(),
+		Title:     New().Bold().Underline(),
+		Value:     New().Bold().Fg(ANSIColor(15)),
+		Progress:  New().Bold().Fg(ANSIColor(10)),
+		Palette: []*Style{
+			New().Bold().Fg(ANSIColor(9)),
+			New().Bold().Fg(ANSIColor(10)),
+			New().Bold().Fg(ANSIColor(11)),
+			New().Bold().Fg(ANSIColor(12)),
+			New().Bold().Fg(ANSIColor(13)),
+			New().Bold().Fg(ANSIColor(14)),
+			New().Bold().Fg(ANSIColor(15)),
+		},
+	}
+
+	// MonochromeTheme 单色主题，只使用粗体、下划线等文本属性
+	MonochromeTheme = &Theme{
+		Error:     New().Bold().Underline(),
+		Success:   New().Bold(),
+		Warning:   New().Bold(),
+		Info:      New(),
+		Remark:    New().Italic(),
+		Muted:     New().Faint(),
+		Accent:    New().Bold(),
+		Prompt:    New().Bold(),
+		Selection: New().Reverse(),
+		Border:    New(),
+		Header:    New().Bold(),
+		Title:     New().Bold().Underline(),
+		Value:     New().Bold(),
+		Progress:  New(),
+		Palette: []*Style{
+			New(),
+			New().Bold(),
+			New().Faint(),
+			New().Underline(),
+			New().Italic(),
+			New().Reverse(),
+		},
+	}
+
+	// LightTheme 浅色背景主题，使用较深的颜色保证在浅色终端上的可读性
+	LightTheme = &Theme{
+		Error:     New().Bold().Fg(MustHex("#b00020")),
+		Success:   New().Bold().Fg(MustHex("#1b5e20")),
+		Warning:   New().Bold().Fg(MustHex("#8a5a00")),
+		Info:      New().Bold().Fg(MustHex("#0d47a1")),
+		Remark:    New().Bold().Fg(MustHex("#006064")),
+		Muted:     New().Fg(MustHex("#8a8a8a")),
+		Accent:    New().Fg(MustHex("#00838f")),
+		Prompt:    New().Bold().Fg(MustHex("#0d47a1")),
+		Selection: New().Bold().Fg(MustHex("#1b5e20")),
+		Border:    New().Fg(MustHex("#757575")),
+		Header:    New().Bold(),
+		Title:     New().Bold().Underline(),
+		Value:     New().Bold(),
+		Progress:  New().Fg(MustHex("#1565c0")),
+		Palette: []*Style{
+			New().Fg(MustHex("#c62828")),
+			New().Fg(MustHex("#2e7d32")),
+			New().Fg(MustHex("#9e7400")),
+			New().Fg(MustHex("#1565c0")),
+			New().Fg(MustHex("#6a1b9a")),
+			New().Fg(MustHex("#00838f")),
+			New().Fg(MustHex("#424242")),
+		},
+	}
+)
+
+// 全局主题
+var (
+	currentTheme   = DefaultTheme
+	currentThemeMu sync.RWMutex
+)
+
+// SetTheme 设置全局主题，所有没有单独设置主题的组件都会使用该主题
+func SetTheme(theme *Theme) {
+	if theme == nil {
+		theme = DefaultTheme
+	}
+	currentThemeMu.Lock()
+	defer currentThemeMu.Unlock()
+	currentTheme = theme
+}
+
+// CurrentTheme 返回当前的全局主题
+func CurrentTheme() *Theme {
+	currentThemeMu.RLock()
+	defer currentThemeMu.RUnlock()
+	return currentTheme
+}
+
+// Copy 返回主题的副本，可以在内置主题的基础上修改部分样式
+func (t *Theme) Copy() *Theme {
+	theme := *t
+	theme.Palette = make([]*Style, len(t.Palette))
+	copy(theme.Palette, t.Palette)
+	return &theme
+}
+
+// PaletteStyle 返回调色板中第 i 个样式（循环使用），调色板为空时返回空样式
+func (t *Theme) PaletteStyle(i int) *Style {
+	if len(t.Palette) == 0 {
+		return New()
+	}
+	return t.Palette[i%len(t.Palette)]
+}
+
+// themeOr 返回组件使用的主题：组件单独设置了主题时使用该主题，否则使用全局主题
+func themeOr(theme *Theme) *Theme {
+	if theme != nil {
+		return theme
+	}
+	return CurrentTheme()
+}
+
+// styleOr 返回组件使用的样式：组件设置了样式时使用该样式，否则使用主题中的样式
+func styleOr(style, fallback *Style) *Style {
+	if style != nil {
+		return style
+	}
+	return fallback
+}
@@ -2,6 +2,7 @@ package goterm
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -113,10 +114,20 @@ func (n *TreeNode) String(depth int, isLast bool, isRoot bool) string {
 
 // Print 打印树形结构
 func (t *Tree) Print() {
-	fmt.Print(t.String())
+	t.Fprint(Output)
+}
+
+// Fprint 打印树形结构到指定的 writer
+func (t *Tree) Fprint(w io.Writer) {
+	fmt.Fprint(w, t.String())
 }
 
 // PrintWithStyle 使用样式打印树形结构
 func (t *Tree) PrintWithStyle(style *Style) {
-	fmt.Print(style.Sprint(t.String()))
+	style.Print(t.String())
+}
+
+// FprintWithStyle 使用样式打印树形结构到指定的 writer（根据该 writer 决定颜色能力）
+func (t *Tree) FprintWithStyle(w io.Writer, style *Style) {
+	style.Fprint(w, t.String())
 }
@@ -0,0 +1,78 @@
+package vt
+
+import "fmt"
+
+// ColorMode 表示颜色的类型
+type ColorMode int
+
+// 颜色的类型
+const (
+	ColorDefault ColorMode = iota // 终端的默认颜色
+	ColorIndexed                  // 256 色调色板中的颜色（0-15 为基本颜色）
+	ColorRGB                      // 24 位真彩色
+)
+
+// Color 表示单元格的前景色或背景色
+type Color struct {
+	Mode    ColorMode // 颜色的类型
+	Index   int       // 调色板索引（Mode 为 ColorIndexed 时有效）
+	R, G, B uint8     // RGB 分量（Mode 为 ColorRGB 时有效）
+}
+
+// DefaultColor 终端的默认颜色
+var DefaultColor = Color{}
+
+// IndexedColor 返回 256 色调色板中的颜色
+func IndexedColor(index int) Color {
+	return Color{Mode: ColorIndexed, Index: index}
+}
+
+// RGBColor 返回 24 位真彩色
+func RGBColor(r, g, b uint8) Color {
+	return Color{Mode: ColorRGB, R: r, G: g, B: b}
+}
+
+// String 返回颜色的文本表示：default、调色板索引或 #rrggbb
+func (c Color) String() string {
+	switch c.Mode {
+	case ColorIndexed:
+		return fmt.Sprintf("%d", c.Index)
+	case ColorRGB:
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	default:
+		return "default"
+	}
+}
+
+// Style 表示单元格的样式
+type Style struct {
+	Fg            Color // 前景色
+	Bg            Color // 背景色
+	Bold          bool  // 粗体
+	Faint         bool  // 暗淡
+	Italic        bool  // 斜体
+	Underline     bool  // 下划线
+	Blink         bool  // 闪烁
+	Reverse       bool  // 反色
+	Hidden        bool  // 隐藏
+	Strikethrough bool  // 删除线
+}
+
+// IsDefault 返回样式是否为终端的默认样式
+func (s Style) IsDefault() bool {
+	return s == Style{}
+}
+
+// Cell 表示屏幕上的一个单元格
+type Cell struct {
+	Rune      rune   // 单元格中的字符，宽字符的第二个单元格为 0
+	Combining string // 附加在字符后面的组合字符、变体选择符和零宽连接的表情符号序列
+	Width     int    // 字符的显示宽度，宽字符的第二个单元格为 0
+	Style     Style  // 字符的样式
+	Link      string // 字符所属超链接的地址，为空时不是超链接
+}
+
+// blankCell 返回使用指定背景色的空白单元格（清除屏幕时保留当前的背景色）
+func blankCell(bg Color) Cell {
+	return Cell{Rune: ' ', Width: 1, Style: Style{Bg: bg}}
+}
@@ -0,0 +1,1002 @@
+// Package vt 提供一个无界面的虚拟终端，支持 VT100/xterm 的常用子集：
+// 光标移动、清除、滚动区域、备用屏幕、SGR 样式和 OSC 8 超链接。
+// 把 goterm 组件的输出写入 Emulator，就可以检查得到的屏幕内容、光标位置和单元格样式，
+// 用于测试基于 goterm 的界面
+package vt
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/lllllan02/goterm"
+)
+
+// 解析器的状态
+type parserState int
+
+const (
+	stateGround    parserState = iota // 普通文本
+	stateEscape                       // 收到 ESC
+	stateCharset                      // 字符集选择（ESC ( 等），忽略下一个字节
+	stateCSI                          // 控制序列（ESC [）
+	stateOSC                          // 操作系统命令（ESC ]）
+	stateOSCEscape                    // 操作系统命令中收到 ESC，等待 \ 结束
+)
+
+// savedCursor 表示保存的光标位置和样式
+type savedCursor struct {
+	x, y  int
+	style Style
+}
+
+// Emulator 表示一个虚拟终端。Emulator 实现了 io.Writer 接口，
+// 写入的内容按照终端的方式解析，换行符同时回到行首（相当于终端驱动的 ONLCR）
+type Emulator struct {
+	width, height int
+	screen        [][]Cell // 当前显示的屏幕
+	main          [][]Cell // 使用备用屏幕时保存的主屏幕
+	alt           bool     // 是否正在使用备用屏幕
+	scrollback    []string // 滚出主屏幕顶部的行
+	maxScrollback int      // 滚动历史最多保留的行数
+
+	x, y        int    // 光标位置（从 0 开始）
+	wrapPending bool   // 光标位于最后一列且已经输出字符，下一个字符需要先换行
+	joinNext    bool   // 上一个字符是零宽连接符，下一个字符附加在前一个字符上
+	style       Style  // 当前的样式
+	link        string // 当前的超链接地址
+	top, bottom int    // 滚动区域（从 0 开始，包含两端）
+	autowrap    bool   // 是否自动换行
+
+	saved    savedCursor // ESC 7 和 CSI s 保存的光标
+	altSaved savedCursor // 进入备用屏幕（?1049h）时保存的光标
+
+	cursorVisible bool               // 光标是否可见
+	cursorShape   goterm.CursorShape // 光标的形状
+	title         string             // 窗口标题
+
+	state    parserState // 解析器的状态
+	sequence []byte      // 正在解析的控制序列或操作系统命令
+	pending  []byte      // 不完整的 UTF-8 字符
+
+	mutex sync.Mutex
+}
+
+// DefaultMaxScrollback 虚拟终端默认保留的滚动历史行数
+const DefaultMaxScrollback = 10000
+
+// NewEmulator 创建一个指定列数和行数的虚拟终端
+func NewEmulator(width, height int) *Emulator {
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	e := &Emulator{width: width, height: height, maxScrollback: DefaultMaxScrollback}
+	e.reset()
+	return e
+}
+
+// SetMaxScrollback 设置滚动历史最多保留的行数，超出时丢弃最早的行，小于等于 0 时不保留滚动历史
+func (e *Emulator) SetMaxScrollback(lines int) *Emulator {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if lines < 0 {
+		lines = 0
+	}
+	e.maxScrollback = lines
+	e.trimScrollback()
+	return e
+}
+
+// Terminal 返回输出到该虚拟终端的 goterm 终端：使用真彩色、输出超链接，
+// 尺寸与虚拟终端相同，输入源为空（需要输入时使用 SetInput 设置）
+func (e *Emulator) Terminal() *goterm.Terminal {
+	term := goterm.NewTerminal(e).SetInput(strings.NewReader(""))
+	term.Renderer().
+		SetColorProfile(goterm.ProfileTrueColor).
+		SetHyperlinks(true).
+		SetHasDarkBackground(true)
+	return term
+}
+
+// Write 实现 io.Writer 接口，解析写入的文本和转义序列
+func (e *Emulator) Write(p []byte) (n int, err error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for _, b := range p {
+		e.feed(b)
+	}
+	return len(p), nil
+}
+
+// WriteString 解析写入的文本和转义序列
+func (e *Emulator) WriteString(s string) (n int, err error) {
+	return e.Write([]byte(s))
+}
+
+// Size 返回虚拟终端的列数和行数
+func (e *Emulator) Size() (columns, rows int) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.width, e.height
+}
+
+// Resize 调整虚拟终端的尺寸，保留左上角的内容，滚动区域恢复为整个屏幕
+func (e *Emulator) Resize(width, height int) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	resize := func(grid [][]Cell) [][]Cell {
+		if grid == nil {
+			return nil
+		}
+		resized := newGrid(width, height)
+		for y := 0; y < height && y < len(grid); y++ {
+			copy(resized[y], grid[y])
+		}
+		return resized
+	}
+	e.screen = resize(e.screen)
+	e.main = resize(e.main)
+	e.width, e.height = width, height
+	e.top, e.bottom = 0, height-1
+	e.x, e.y = clamp(e.x, 0, width-1), clamp(e.y, 0, height-1)
+	e.wrapPending = false
+}
+
+// Cursor 返回光标的位置（列和行均从 0 开始）
+func (e *Emulator) Cursor() (x, y int) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.x, e.y
+}
+
+// CursorVisible 返回光标是否可见
+func (e *Emulator) CursorVisible() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.cursorVisible
+}
+
+// CursorShape 返回光标的形状
+func (e *Emulator) CursorShape() goterm.CursorShape {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.cursorShape
+}
+
+// IsAltScreen 返回是否正在使用备用屏幕
+func (e *Emulator) IsAltScreen() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.alt
+}
+
+// Title 返回通过 OSC 0 或 OSC 2 设置的窗口标题
+func (e *Emulator) Title() string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.title
+}
+
+// ScrollRegion 返回滚动区域的第一行和最后一行（从 0 开始）
+func (e *Emulator) ScrollRegion() (top, bottom int) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.top, e.bottom
+}
+
+// Cell 返回指定位置（从 0 开始）的单元格，超出屏幕范围时返回空白单元格
+func (e *Emulator) Cell(x, y int) Cell {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if x < 0 || y < 0 || x >= e.width || y >= e.height {
+		return blankCell(DefaultColor)
+	}
+	return e.screen[y][x]
+}
+
+// Line 返回一行的文本（不包含样式），行尾的空白被去除
+func (e *Emulator) Line(y int) string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if y < 0 || y >= e.height {
+		return ""
+	}
+	return rowText(e.screen[y])
+}
+
+// Lines 返回屏幕上每一行的文本
+func (e *Emulator) Lines() []string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	lines := make([]string, e.height)
+	for y, row := range e.screen {
+		lines[y] = rowText(row)
+	}
+	return lines
+}
+
+// String 返回屏幕内容的快照：每行去除行尾空白，并去除屏幕底部的空行
+func (e *Emulator) String() string {
+	lines := e.Lines()
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Scrollback 返回滚出主屏幕顶部的行（最早的行在前）
+func (e *Emulator) Scrollback() []string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return append([]string(nil), e.scrollback...)
+}
+
+// reset 将虚拟终端恢复到初始状态
+func (e *Emulator) reset() {
+	e.screen = newGrid(e.width, e.height)
+	e.main = nil
+	e.alt = false
+	e.scrollback = nil
+	e.x, e.y = 0, 0
+	e.wrapPending = false
+	e.style = Style{}
+	e.link = ""
+	e.top, e.bottom = 0, e.height-1
+	e.autowrap = true
+	e.saved = savedCursor{}
+	e.altSaved = savedCursor{}
+	e.cursorVisible = true
+	e.cursorShape = goterm.CursorShapeDefault
+	e.state = stateGround
+}
+
+// feed 解析一个字节
+func (e *Emulator) feed(b byte) {
+	switch e.state {
+	case stateGround:
+		e.ground(b)
+	case stateEscape:
+		e.escape(b)
+	case stateCharset:
+		e.state = stateGround
+	case stateCSI:
+		if b >= 0x40 && b <= 0x7E {
+			e.state = stateGround
+			e.dispatchCSI(string(e.sequence), b)
+			return
+		}
+		e.sequence = append(e.sequence, b)
+	case stateOSC:
+		switch b {
+		case '\a':
+			e.state = stateGround
+			e.dispatchOSC(string(e.sequence))
+		case 0x1B:
+			e.state = stateOSCEscape
+		default:
+			e.sequence = append(e.sequence, b)
+		}
+	case stateOSCEscape:
+		e.state = stateGround
+		e.dispatchOSC(string(e.sequence))
+		if b != '\\' {
+			e.escape(b)
+		}
+	}
+}
+
+// ground 处理普通文本中的字节
+func (e *Emulator) ground(b byte) {
+	if len(e.pending) == 0 {
+		switch {
+		case b == 0x1B:
+			e.state = stateEscape
+			return
+		case b < 0x20 || b == 0x7F:
+			e.control(b)
+			return
+		case b < utf8.RuneSelf:
+			e.print(rune(b))
+			return
+		}
+	}
+
+	e.pending = append(e.pending, b)
+	if !utf8.FullRune(e.pending) {
+		return
+	}
+	r, _ := utf8.DecodeRune(e.pending)
+	e.pending = e.pending[:0]
+	e.print(r)
+}
+
+// control 处理控制字符
+func (e *Emulator) control(b byte) {
+	switch b {
+	case '\b':
+		if e.x > 0 {
+			e.x--
+		}
+		e.wrapPending = false
+	case '\t':
+		e.x = clamp((e.x/8+1)*8, 0, e.width-1)
+		e.wrapPending = false
+	case '\n', '\v', '\f':
+		e.index()
+		e.x = 0
+	case '\r':
+		e.x = 0
+		e.wrapPending = false
+	}
+}
+
+// escape 处理 ESC 之后的字节
+func (e *Emulator) escape(b byte) {
+	e.state = stateGround
+	switch b {
+	case '[':
+		e.state = stateCSI
+		e.sequence = e.sequence[:0]
+	case ']':
+		e.state = stateOSC
+		e.sequence = e.sequence[:0]
+	case '(', ')', '*', '+':
+		e.state = stateCharset
+	case '7':
+		e.saved = e.saveCursor()
+	case '8':
+		e.restoreCursor(e.saved)
+	case 'D':
+		e.index()
+	case 'E':
+		e.index()
+		e.x = 0
+	case 'M':
+		e.reverseIndex()
+	case 'c':
+		e.reset()
+	}
+}
+
+// print 在光标位置输出一个字符
+func (e *Emulator) print(r rune) {
+	joined := e.joinNext
+	e.joinNext = r == 0x200D
+
+	// 组合字符、零宽连接符后面的字符、肤色修饰符和国旗的第二个区域指示符
+	// 与前一个字符组成一个字素簇，附加在前一个字符上，不占用单元格
+	width := goterm.RuneWidth(r)
+	prev := e.previousCell()
+	switch {
+	case width == 0 && r < 0xA0: // 控制字符
+		return
+	case prev != nil && (width == 0 || joined || isEmojiModifier(r)),
+		prev != nil && isRegionalIndicator(r) && isRegionalIndicator(prev.Rune) && prev.Combining == "":
+		prev.Combining += string(r)
+		return
+	case width == 0:
+		return
+	case width > e.width:
+		// 屏幕只有一列时宽字符无法显示，使用空格代替
+		r, width = ' ', 1
+	}
+
+	if e.wrapPending && e.autowrap {
+		e.index()
+		e.x = 0
+	}
+	e.wrapPending = false
+
+	if e.x+width > e.width {
+		// 宽字符放不下时换到下一行
+		if !e.autowrap {
+			e.x = e.width - width
+		} else {
+			for x := e.x; x < e.width; x++ {
+				e.screen[e.y][x] = blankCell(e.style.Bg)
+			}
+			e.index()
+			e.x = 0
+		}
+	}
+
+	row := e.screen[e.y]
+	e.clearWide(row, e.x)
+	if width == 2 {
+		e.clearWide(row, e.x+1)
+	}
+	row[e.x] = Cell{Rune: r, Width: width, Style: e.style, Link: e.link}
+	if width == 2 {
+		row[e.x+1] = Cell{Width: 0, Style: e.style, Link: e.link}
+	}
+
+	e.x += width
+	if e.x >= e.width {
+		e.x = e.width - 1
+		e.wrapPending = e.autowrap
+	}
+}
+
+// previousCell 返回光标前面的字符所在的单元格，没有时返回 nil
+func (e *Emulator) previousCell() *Cell {
+	row := e.screen[e.y]
+	x := e.x - 1
+	if e.wrapPending {
+		x = e.x
+	}
+	if x >= 0 && row[x].Width == 0 {
+		x--
+	}
+	if x < 0 || row[x].Width == 0 {
+		return nil
+	}
+	return &row[x]
+}
+
+// isRegionalIndicator 判断字符是否为区域指示符，两个区域指示符组成一个国旗
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isEmojiModifier 判断字符是否为表情符号的肤色修饰符
+func isEmojiModifier(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+// clearWide 覆盖单元格之前，清除该单元格所属宽字符的另一半
+func (e *Emulator) clearWide(row []Cell, x int) {
+	if x >= len(row) {
+		return
+	}
+	switch {
+	case row[x].Width == 0 && x > 0:
+		row[x-1] = blankCell(row[x-1].Style.Bg)
+	case row[x].Width == 2 && x+1 < len(row):
+		row[x+1] = blankCell(row[x+1].Style.Bg)
+	}
+}
+
+// index 将光标下移一行，光标位于滚动区域底部时滚动区域向上滚动
+func (e *Emulator) index() {
+	e.wrapPending = false
+	switch {
+	case e.y == e.bottom:
+		e.scrollUp(1)
+	case e.y < e.height-1:
+		e.y++
+	}
+}
+
+// reverseIndex 将光标上移一行，光标位于滚动区域顶部时滚动区域向下滚动
+func (e *Emulator) reverseIndex() {
+	e.wrapPending = false
+	switch {
+	case e.y == e.top:
+		e.scrollDown(1)
+	case e.y > 0:
+		e.y--
+	}
+}
+
+// scrollUp 将滚动区域向上滚动 n 行，主屏幕顶部滚出的行进入滚动历史。
+// 滚动的行数不超过滚动区域的高度，超出的部分只会滚出空行
+func (e *Emulator) scrollUp(n int) {
+	n = min(n, e.bottom-e.top+1)
+	for i := 0; i < n; i++ {
+		if !e.alt && e.top == 0 {
+			e.scrollback = append(e.scrollback, rowText(e.screen[e.top]))
+		}
+		copy(e.screen[e.top:e.bottom], e.screen[e.top+1:e.bottom+1])
+		e.screen[e.bottom] = newRow(e.width, e.style.Bg)
+	}
+	e.trimScrollback()
+}
+
+// trimScrollback 丢弃超出保留行数的最早的滚动历史（丢弃的行在 append 重新分配时释放）
+func (e *Emulator) trimScrollback() {
+	if extra := len(e.scrollback) - e.maxScrollback; extra > 0 {
+		e.scrollback = e.scrollback[extra:]
+	}
+}
+
+// scrollDown 将滚动区域向下滚动 n 行，行数不超过滚动区域的高度
+func (e *Emulator) scrollDown(n int) {
+	n = min(n, e.bottom-e.top+1)
+	for i := 0; i < n; i++ {
+		copy(e.screen[e.top+1:e.bottom+1], e.screen[e.top:e.bottom])
+		e.screen[e.top] = newRow(e.width, e.style.Bg)
+	}
+}
+
+// saveCursor 返回当前的光标位置和样式
+func (e *Emulator) saveCursor() savedCursor {
+	return savedCursor{x: e.x, y: e.y, style: e.style}
+}
+
+// restoreCursor 恢复保存的光标位置和样式
+func (e *Emulator) restoreCursor(saved savedCursor) {
+	e.x, e.y = clamp(saved.x, 0, e.width-1), clamp(saved.y, 0, e.height-1)
+	e.style = saved.style
+	e.wrapPending = false
+}
+
+// moveTo 将光标移动到指定位置（从 0 开始）
+func (e *Emulator) moveTo(x, y int) {
+	e.x, e.y = clamp(x, 0, e.width-1), clamp(y, 0, e.height-1)
+	e.wrapPending = false
+}
+
+// dispatchCSI 执行控制序列，sequence 为 ESC [ 和结束字符之间的内容
+func (e *Emulator) dispatchCSI(sequence string, final byte) {
+	private := byte(0)
+	if sequence != "" && strings.IndexByte("?<=>", sequence[0]) >= 0 {
+		private = sequence[0]
+		sequence = sequence[1:]
+	}
+	intermediate := ""
+	if i := strings.IndexFunc(sequence, func(r rune) bool { return r >= 0x20 && r <= 0x2F }); i >= 0 {
+		intermediate = sequence[i:]
+		sequence = sequence[:i]
+	}
+	groups := parseParams(sequence)
+	params := make([]int, len(groups))
+	for i, group := range groups {
+		params[i] = group[0]
+	}
+	param := func(i, def int) int {
+		if i < len(params) && params[i] > 0 {
+			return params[i]
+		}
+		return def
+	}
+
+	switch {
+	case private == '?':
+		if final == 'h' || final == 'l' {
+			for _, mode := range params {
+				e.setMode(mode, final == 'h')
+			}
+		}
+		return
+	case private != 0:
+		return
+	case intermediate == " " && final == 'q':
+		e.cursorShape = goterm.CursorShape(param(0, 0))
+		return
+	case intermediate != "":
+		return
+	}
+
+	switch final {
+	case 'A':
+		e.cursorUp(param(0, 1))
+	case 'B', 'e':
+		e.cursorDown(param(0, 1))
+	case 'C', 'a':
+		e.moveTo(e.x+param(0, 1), e.y)
+	case 'D':
+		e.moveTo(e.x-param(0, 1), e.y)
+	case 'E':
+		e.cursorDown(param(0, 1))
+		e.x = 0
+	case 'F':
+		e.cursorUp(param(0, 1))
+		e.x = 0
+	case 'G', '`':
+		e.moveTo(param(0, 1)-1, e.y)
+	case 'd':
+		e.moveTo(e.x, param(0, 1)-1)
+	case 'H', 'f':
+		e.moveTo(param(1, 1)-1, param(0, 1)-1)
+	case 'J':
+		e.eraseDisplay(param(0, 0))
+	case 'K':
+		e.eraseLine(param(0, 0))
+	case 'X':
+		row := e.screen[e.y]
+		for x := e.x; x < e.x+param(0, 1) && x < e.width; x++ {
+			row[x] = blankCell(e.style.Bg)
+		}
+	case 'P':
+		e.deleteChars(param(0, 1))
+	case '@':
+		e.insertChars(param(0, 1))
+	case 'L':
+		e.insertLines(param(0, 1))
+	case 'M':
+		e.deleteLines(param(0, 1))
+	case 'S':
+		e.scrollUp(param(0, 1))
+	case 'T':
+		e.scrollDown(param(0, 1))
+	case 'm':
+		e.selectGraphicRendition(groups)
+	case 'r':
+		top, bottom := param(0, 1)-1, param(1, e.height)-1
+		if top < bottom && bottom < e.height {
+			e.top, e.bottom = top, bottom
+		} else {
+			e.top, e.bottom = 0, e.height-1
+		}
+		e.moveTo(0, 0)
+	case 's':
+		e.saved = e.saveCursor()
+	case 'u':
+		e.restoreCursor(e.saved)
+	}
+}
+
+// setMode 开启或关闭 DEC 私有模式
+func (e *Emulator) setMode(mode int, on bool) {
+	switch mode {
+	case 7:
+		e.autowrap = on
+	case 25:
+		e.cursorVisible = on
+	case 47, 1047:
+		e.switchScreen(on)
+	case 1049:
+		if on {
+			e.altSaved = e.saveCursor()
+			e.switchScreen(true)
+		} else {
+			e.switchScreen(false)
+			e.restoreCursor(e.altSaved)
+		}
+	}
+}
+
+// switchScreen 切换到备用屏幕（备用屏幕总是从空白开始）或切换回主屏幕
+func (e *Emulator) switchScreen(alt bool) {
+	if alt == e.alt {
+		return
+	}
+	if alt {
+		e.main = e.screen
+		e.screen = newGrid(e.width, e.height)
+	} else {
+		e.screen = e.main
+		e.main = nil
+	}
+	e.alt = alt
+}
+
+// cursorUp 将光标上移 n 行，光标位于滚动区域内时不会移出滚动区域
+func (e *Emulator) cursorUp(n int) {
+	top := 0
+	if e.y >= e.top {
+		top = e.top
+	}
+	e.moveTo(e.x, max(e.y-n, top))
+}
+
+// cursorDown 将光标下移 n 行，光标位于滚动区域内时不会移出滚动区域
+func (e *Emulator) cursorDown(n int) {
+	bottom := e.height - 1
+	if e.y <= e.bottom {
+		bottom = e.bottom
+	}
+	e.moveTo(e.x, min(e.y+n, bottom))
+}
+
+// eraseDisplay 清除屏幕：0 清除到屏幕底部，1 清除到屏幕顶部，2 清除整个屏幕，3 清除滚动历史
+func (e *Emulator) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		e.eraseLine(0)
+		for y := e.y + 1; y < e.height; y++ {
+			e.screen[y] = newRow(e.width, e.style.Bg)
+		}
+	case 1:
+		e.eraseLine(1)
+		for y := 0; y < e.y; y++ {
+			e.screen[y] = newRow(e.width, e.style.Bg)
+		}
+	case 2:
+		for y := range e.screen {
+			e.screen[y] = newRow(e.width, e.style.Bg)
+		}
+	case 3:
+		e.scrollback = nil
+	}
+}
+
+// eraseLine 清除光标所在的行：0 清除到行尾，1 清除到行首，2 清除整行
+func (e *Emulator) eraseLine(mode int) {
+	start, end := e.x, e.width
+	switch mode {
+	case 1:
+		start, end = 0, e.x+1
+	case 2:
+		start, end = 0, e.width
+	}
+	row := e.screen[e.y]
+	e.clearWide(row, start)
+	e.clearWide(row, end-1)
+	for x := start; x < end; x++ {
+		row[x] = blankCell(e.style.Bg)
+	}
+}
+
+// deleteChars 删除光标位置开始的 n 个字符，右侧的字符左移
+func (e *Emulator) deleteChars(n int) {
+	row := e.screen[e.y]
+	n = min(n, e.width-e.x)
+	copy(row[e.x:], row[e.x+n:])
+	for x := e.width - n; x < e.width; x++ {
+		row[x] = blankCell(e.style.Bg)
+	}
+}
+
+// insertChars 在光标位置插入 n 个空白，右侧的字符右移
+func (e *Emulator) insertChars(n int) {
+	row := e.screen[e.y]
+	n = min(n, e.width-e.x)
+	copy(row[e.x+n:], row[e.x:e.width-n])
+	for x := e.x; x < e.x+n; x++ {
+		row[x] = blankCell(e.style.Bg)
+	}
+}
+
+// insertLines 在光标所在行插入 n 个空行，滚动区域内下方的行下移
+func (e *Emulator) insertLines(n int) {
+	if e.y < e.top || e.y > e.bottom {
+		return
+	}
+	top := e.top
+	e.top = e.y
+	e.scrollDown(min(n, e.bottom-e.y+1))
+	e.top = top
+	e.x = 0
+}
+
+// deleteLines 删除光标所在行开始的 n 行，滚动区域内下方的行上移
+func (e *Emulator) deleteLines(n int) {
+	if e.y < e.top || e.y > e.bottom {
+		return
+	}
+	top, alt := e.top, e.alt
+	e.top = e.y
+	// 删除的行不进入滚动历史
+	e.alt = true
+	e.scrollUp(min(n, e.bottom-e.y+1))
+	e.top, e.alt = top, alt
+	e.x = 0
+}
+
+// selectGraphicRendition 根据 SGR 参数更新当前的样式
+func (e *Emulator) selectGraphicRendition(groups [][]int) {
+	if len(groups) == 0 {
+		e.style = Style{}
+		return
+	}
+
+	// 以分号分隔的扩展颜色（38;5;n）的参数是后面的主参数
+	params := make([]int, len(groups))
+	for i, group := range groups {
+		params[i] = group[0]
+	}
+
+	s := &e.style
+	for i := 0; i < len(params); i++ {
+		if sub := groups[i][1:]; len(sub) > 0 {
+			e.subParameters(params[i], sub)
+			continue
+		}
+		switch p := params[i]; {
+		case p == 0:
+			*s = Style{}
+		case p == 1:
+			s.Bold = true
+		case p == 2:
+			s.Faint = true
+		case p == 3:
+			s.Italic = true
+		case p == 4, p == 21:
+			s.Underline = true
+		case p == 5, p == 6:
+			s.Blink = true
+		case p == 7:
+			s.Reverse = true
+		case p == 8:
+			s.Hidden = true
+		case p == 9:
+			s.Strikethrough = true
+		case p == 22:
+			s.Bold, s.Faint = false, false
+		case p == 23:
+			s.Italic = false
+		case p == 24:
+			s.Underline = false
+		case p == 25:
+			s.Blink = false
+		case p == 27:
+			s.Reverse = false
+		case p == 28:
+			s.Hidden = false
+		case p == 29:
+			s.Strikethrough = false
+		case p >= 30 && p <= 37:
+			s.Fg = IndexedColor(p - 30)
+		case p == 38:
+			s.Fg, i = extendedColor(params, i)
+		case p == 39:
+			s.Fg = DefaultColor
+		case p >= 40 && p <= 47:
+			s.Bg = IndexedColor(p - 40)
+		case p == 48:
+			s.Bg, i = extendedColor(params, i)
+		case p == 49:
+			s.Bg = DefaultColor
+		case p == 58:
+			// 下划线颜色不记录，只跳过其参数
+			_, i = extendedColor(params, i)
+		case p >= 90 && p <= 97:
+			s.Fg = IndexedColor(p - 90 + 8)
+		case p >= 100 && p <= 107:
+			s.Bg = IndexedColor(p - 100 + 8)
+		}
+	}
+}
+
+// subParameters 处理带有冒号分隔的子参数的 SGR 参数，例如 4:3（波浪下划线）和 38:2::r:g:b
+func (e *Emulator) subParameters(p int, sub []int) {
+	s := &e.style
+	switch p {
+	case 4:
+		// 4:0 关闭下划线，4:1 到 4:5 是各种样式的下划线
+		s.Underline = sub[0] != 0
+	case 38:
+		if c, ok := subColor(sub); ok {
+			s.Fg = c
+		}
+	case 48:
+		if c, ok := subColor(sub); ok {
+			s.Bg = c
+		}
+	case 58:
+		// 下划线颜色不记录
+	default:
+		e.selectGraphicRendition([][]int{{p}})
+	}
+}
+
+// subColor 解析冒号形式的扩展颜色：5:索引、2:色彩空间:R:G:B 或省略色彩空间的 2:R:G:B
+func subColor(sub []int) (Color, bool) {
+	switch {
+	case sub[0] == 5 && len(sub) >= 2:
+		return IndexedColor(sub[1]), true
+	case sub[0] == 2 && len(sub) >= 5:
+		return RGBColor(uint8(sub[2]), uint8(sub[3]), uint8(sub[4])), true
+	case sub[0] == 2 && len(sub) == 4:
+		return RGBColor(uint8(sub[1]), uint8(sub[2]), uint8(sub[3])), true
+	}
+	return DefaultColor, false
+}
+
+// extendedColor 解析 38、48、58 之后的扩展颜色参数（5;索引 或 2;R;G;B），返回颜色和最后一个参数的位置
+func extendedColor(params []int, i int) (Color, int) {
+	if i+1 >= len(params) {
+		return DefaultColor, i
+	}
+	switch params[i+1] {
+	case 5:
+		if i+2 < len(params) {
+			return IndexedColor(params[i+2]), i + 2
+		}
+	case 2:
+		if i+4 < len(params) {
+			return RGBColor(uint8(params[i+2]), uint8(params[i+3]), uint8(params[i+4])), i + 4
+		}
+	}
+	return DefaultColor, len(params)
+}
+
+// dispatchOSC 执行操作系统命令，支持 OSC 8 超链接和 OSC 0、OSC 2 窗口标题
+func (e *Emulator) dispatchOSC(command string) {
+	i := strings.IndexByte(command, ';')
+	if i < 0 {
+		return
+	}
+	switch command[:i] {
+	case "0", "2":
+		e.title = command[i+1:]
+	case "8":
+		// 格式为 8 ; 参数 ; 地址，地址为空表示关闭超链接
+		rest := command[i+1:]
+		if j := strings.IndexByte(rest, ';'); j >= 0 {
+			e.link = rest[j+1:]
+		}
+	}
+}
+
+// parseParams 解析以分号分隔的数字参数，空参数为 0。每个参数是一个列表：
+// 第一个元素是参数本身，其余元素是以冒号分隔的子参数（例如 4:3 和 38:2::r:g:b）
+func parseParams(s string) [][]int {
+	if s == "" {
+		return nil
+	}
+	fields := strings.Split(s, ";")
+	params := make([][]int, len(fields))
+	for i, field := range fields {
+		parts := strings.Split(field, ":")
+		params[i] = make([]int, len(parts))
+		for j, part := range parts {
+			params[i][j], _ = strconv.Atoi(part)
+		}
+	}
+	return params
+}
+
+// newGrid 创建一个空白的屏幕
+func newGrid(width, height int) [][]Cell {
+	grid := make([][]Cell, height)
+	for y := range grid {
+		grid[y] = newRow(width, DefaultColor)
+	}
+	return grid
+}
+
+// newRow 创建一个使用指定背景色的空白行
+func newRow(width int, bg Color) []Cell {
+	row := make([]Cell, width)
+	for x := range row {
+		row[x] = blankCell(bg)
+	}
+	return row
+}
+
+// rowText 返回一行的文本，行尾的空白被去除
+func rowText(row []Cell) string {
+	var text strings.Builder
+	for _, c := range row {
+		if c.Width > 0 {
+			text.WriteRune(c.Rune)
+			text.WriteString(c.Combining)
+		}
+	}
+	return strings.TrimRight(text.String(), " ")
+}
+
+// clamp 将 v 限制在 [low, high] 范围内
+func clamp(v, low, high int) int {
+	if v < low {
+		return low
+	}
+	if v > high {
+		return high
+	}
+	return v
+}
+
+// min 返回较小的值
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// max 返回较大的值
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
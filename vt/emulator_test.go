@@ -0,0 +1,210 @@
+package vt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lllllan02/goterm"
+)
+
+func TestWideRuneAtEdge(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		input         string
+		want          string
+		x, y          int
+	}{
+		{"wraps to next line", 3, 2, "ab中", "ab\n中", 2, 1},
+		{"fits exactly", 4, 2, "ab中", "ab中", 3, 0},
+		{"one column autowrap", 1, 2, "中", "", 0, 0},
+		{"one column no autowrap", 1, 2, "\x1b[?7l中", "", 0, 0},
+		{"no autowrap overwrites last cells", 3, 1, "\x1b[?7labc中", "a中", 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEmulator(tt.width, tt.height)
+			e.WriteString(tt.input)
+			if got := e.String(); got != tt.want {
+				t.Errorf("screen = %q, want %q", got, tt.want)
+			}
+			if x, y := e.Cursor(); x != tt.x || y != tt.y {
+				t.Errorf("cursor = (%d, %d), want (%d, %d)", x, y, tt.x, tt.y)
+			}
+		})
+	}
+}
+
+func TestSelectGraphicRendition(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Style
+	}{
+		{"bold and color", "\x1b[1;31m", Style{Bold: true, Fg: IndexedColor(1)}},
+		{"bright colors", "\x1b[92;104m", Style{Fg: IndexedColor(10), Bg: IndexedColor(12)}},
+		{"256 colors", "\x1b[38;5;208;48;5;17m", Style{Fg: IndexedColor(208), Bg: IndexedColor(17)}},
+		{"true color", "\x1b[38;2;1;2;3m", Style{Fg: RGBColor(1, 2, 3)}},
+		{"reset", "\x1b[1;3m\x1b[0m", Style{}},
+		{"empty resets", "\x1b[1m\x1b[m", Style{}},
+		{"curly underline", goterm.CurlyUnderline, Style{Underline: true}},
+		{"double underline", goterm.DoubleUnderline, Style{Underline: true}},
+		{"underline off", "\x1b[4m\x1b[4:0m", Style{}},
+		{"colon true color", "\x1b[38:2::10:20:30m", Style{Fg: RGBColor(10, 20, 30)}},
+		{"colon true color without color space", "\x1b[48:2:10:20:30m", Style{Bg: RGBColor(10, 20, 30)}},
+		{"colon indexed", "\x1b[38:5:100m", Style{Fg: IndexedColor(100)}},
+		{"underline color ignored", "\x1b[58:2::1:2:3;1m", Style{Bold: true}},
+		{"underline color semicolon form", "\x1b[58;5;3;3m", Style{Italic: true}},
+		{"mixed", "\x1b[1;4:3;38:5:2m", Style{Bold: true, Underline: true, Fg: IndexedColor(2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEmulator(10, 1)
+			e.WriteString(tt.input + "x")
+			if got := e.Cell(0, 0).Style; got != tt.want {
+				t.Errorf("style = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmulatorScreen(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		input  string
+		want   string
+		x, y   int
+	}{
+		{"plain text", 10, 3, "hello", "hello", 5, 0},
+		{"newline returns to column 0", 10, 3, "ab\ncd", "ab\ncd", 2, 1},
+		{"carriage return overwrites", 10, 3, "abc\rX", "Xbc", 1, 0},
+		{"backspace", 10, 3, "abc\b\bX", "aXc", 2, 0},
+		{"tab stops", 20, 1, "a\tb", "a       b", 9, 0},
+		{"autowrap", 3, 3, "abcde", "abc\nde", 2, 1},
+		{"wrap pending at last column", 3, 3, "abc", "abc", 2, 0},
+		{"scrolls at bottom", 3, 2, "a\nb\nc", "b\nc", 1, 1},
+
+		{"cursor position", 10, 3, "\x1b[2;3HX", "\n  X", 3, 1},
+		{"cursor position defaults", 10, 3, "ab\x1b[HX", "Xb", 1, 0},
+		{"cursor position clamped", 5, 2, "\x1b[9;9HX", "\n    X", 4, 1},
+		{"cursor up down forward back", 10, 4, "\x1b[3;5H\x1b[2A\x1b[3C\x1b[1B\x1b[2DX", "\n     X", 6, 1},
+		{"cursor next and previous line", 10, 4, "abc\x1b[2EX\x1b[FY", "abc\nY\nX", 1, 1},
+		{"column and row absolute", 10, 3, "\x1b[3d\x1b[4GX", "\n\n   X", 4, 2},
+		{"save and restore cursor", 10, 3, "ab\x1b7\x1b[3;1Hcd\x1b8X", "abX\n\ncd", 3, 0},
+
+		{"erase to end of line", 10, 2, "abcdef\x1b[3D\x1b[K", "abc", 3, 0},
+		{"erase to start of line", 10, 2, "abcdef\x1b[3D\x1b[1K", "    ef", 3, 0},
+		{"erase display below", 10, 3, "aa\nbb\ncc\x1b[2;2H\x1b[J", "aa\nb", 1, 1},
+		{"erase display", 10, 3, "aa\nbb\x1b[2J", "", 2, 1},
+		{"erase characters", 10, 1, "abcdef\x1b[1G\x1b[2X", "  cdef", 0, 0},
+		{"delete characters", 10, 1, "abcdef\x1b[1G\x1b[2P", "cdef", 0, 0},
+		{"insert characters", 10, 1, "abcdef\x1b[1G\x1b[2@", "  abcdef", 0, 0},
+		{"insert lines", 10, 3, "aa\nbb\ncc\x1b[2H\x1b[L", "aa\n\nbb", 0, 1},
+		{"delete lines", 10, 3, "aa\nbb\ncc\x1b[1H\x1b[M", "bb\ncc", 0, 0},
+
+		{"scroll region index", 5, 4, "top\x1b[2;3r\x1b[3;1Hx\ny\nz\x1b[4;1Hbot", "top\ny\nz\nbot", 3, 3},
+		{"scroll region reverse index", 5, 4, "a\nb\nc\nd\x1b[2;3r\x1b[2;1H\x1bM", "a\n\nb\nd", 0, 1},
+		{"scroll up and down", 5, 3, "a\nb\nc\x1b[S", "b\nc", 1, 2},
+		{"scroll down", 5, 3, "a\nb\nc\x1b[T", "\na\nb", 1, 2},
+		{"huge scroll up", 10, 3, "a\nb\nc\x1b[50000000S", "", 1, 2},
+		{"huge scroll down", 10, 3, "a\nb\nc\x1b[50000000T", "", 1, 2},
+		{"huge scroll in region", 5, 4, "top\x1b[2;3r\x1b[2;1Hx\ny\x1b[4;1Hbot\x1b[99999999999999999999S", "top\n\n\nbot", 3, 3},
+		{"no autowrap", 3, 2, "\x1b[?7labcde", "abe", 2, 0},
+
+		{"alternate screen keeps cursor", 10, 3, "main\x1b[?1049halt", "    alt", 7, 0},
+		{"alternate screen restored", 10, 3, "main\x1b[?1049h\x1b[2;2Halt\x1b[?1049l", "main", 4, 0},
+		{"alternate screen starts blank", 10, 3, "main\x1b[?1049h\x1b[?1049l\x1b[?1049h", "", 4, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEmulator(tt.width, tt.height)
+			e.WriteString(tt.input)
+			if got := e.String(); got != tt.want {
+				t.Errorf("screen = %q, want %q", got, tt.want)
+			}
+			if x, y := e.Cursor(); x != tt.x || y != tt.y {
+				t.Errorf("cursor = (%d, %d), want (%d, %d)", x, y, tt.x, tt.y)
+			}
+		})
+	}
+}
+
+func TestEmulatorState(t *testing.T) {
+	e := NewEmulator(20, 3)
+	e.WriteString("\x1b]2;my title\x07\x1b[?25l\x1b[5 q\x1b[2;3r")
+	e.WriteString("\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\ x")
+
+	if got := e.Title(); got != "my title" {
+		t.Errorf("title = %q", got)
+	}
+	if e.CursorVisible() {
+		t.Error("cursor should be hidden")
+	}
+	if got := e.CursorShape(); got != goterm.CursorShape(5) {
+		t.Errorf("cursor shape = %v", got)
+	}
+	if top, bottom := e.ScrollRegion(); top != 1 || bottom != 2 {
+		t.Errorf("scroll region = %d, %d", top, bottom)
+	}
+	if got := e.Cell(0, 0).Link; got != "https://example.com" {
+		t.Errorf("link = %q", got)
+	}
+	if got := e.Cell(5, 0).Link; got != "" {
+		t.Errorf("link after close = %q", got)
+	}
+	if e.IsAltScreen() {
+		t.Error("should be on the main screen")
+	}
+	e.WriteString("\x1b[?1049h")
+	if !e.IsAltScreen() {
+		t.Error("should be on the alternate screen")
+	}
+}
+
+func TestEmulatorScrollback(t *testing.T) {
+	e := NewEmulator(5, 2)
+	e.WriteString("one\ntwo\nthree\nfour")
+	if got, want := e.Scrollback(), []string{"one", "two"}; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("scrollback = %q, want %q", got, want)
+	}
+
+	// 备用屏幕中的滚动不进入滚动历史
+	e.WriteString("\x1b[?1049ha\nb\nc")
+	if got := len(e.Scrollback()); got != 2 {
+		t.Errorf("scrollback length = %d, want 2", got)
+	}
+
+	// 滚动的行数不超过屏幕高度
+	e = NewEmulator(10, 3)
+	e.WriteString("a\nb\nc\x1b[50000000S")
+	if got, want := e.Scrollback(), []string{"a", "b", "c"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("scrollback = %q, want %q", got, want)
+	}
+
+	// 滚动历史超出保留的行数时丢弃最早的行
+	e = NewEmulator(10, 1).SetMaxScrollback(2)
+	e.WriteString("1\n2\n3\n4")
+	if got, want := e.Scrollback(), []string{"2", "3"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("scrollback = %q, want %q", got, want)
+	}
+	e.SetMaxScrollback(0)
+	if got := e.Scrollback(); len(got) != 0 {
+		t.Errorf("scrollback = %q, want none", got)
+	}
+}
+
+func TestEmulatorGraphemes(t *testing.T) {
+	e := NewEmulator(10, 1)
+	e.WriteString("é🇨🇳👍🏽x")
+	if got, want := e.String(), "é🇨🇳👍🏽x"; got != want {
+		t.Errorf("screen = %q, want %q", got, want)
+	}
+	if x, _ := e.Cursor(); x != 6 {
+		t.Errorf("cursor x = %d, want 6", x)
+	}
+	if c := e.Cell(1, 0); c.Rune != 0x1F1E8 || c.Width != 2 {
+		t.Errorf("flag cell = %+v", c)
+	}
+}
@@ -0,0 +1,152 @@
+package goterm
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// runeRange 表示一段连续的码位（包含两端）
+type runeRange struct {
+	first, last rune
+}
+
+// wideRanges 东亚宽度为 W（宽）或 F（全角）的字符，根据 Unicode 14.0 的 EastAsianWidth.txt 生成，
+// 包括中日韩文字、全角符号和默认以表情形式显示的符号
+var wideRanges = []runeRange{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0},
+	{0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267F, 0x267F},
+	{0x2693, 0x2693}, {0x26A1, 0x26A1}, {0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5},
+	{0x26CE, 0x26CE}, {0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B}, {0x2728, 0x2728},
+	{0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27B0, 0x27B0}, {0x27BF, 0x27BF}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55},
+	{0x2E80, 0x2E99}, {0x2E9B, 0x2EF3}, {0x2F00, 0x2FD5}, {0x2FF0, 0x2FFB}, {0x3000, 0x303E},
+	{0x3041, 0x3096}, {0x3099, 0x30FF}, {0x3105, 0x312F}, {0x3131, 0x318E}, {0x3190, 0x31E3},
+	{0x31F0, 0x321E}, {0x3220, 0x3247}, {0x3250, 0x4DBF}, {0x4E00, 0xA48C}, {0xA490, 0xA4C6},
+	{0xA960, 0xA97C}, {0xAC00, 0xD7A3}, {0xF900, 0xFA6D}, {0xFA70, 0xFAD9}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE52}, {0xFE54, 0xFE66}, {0xFE68, 0xFE6B}, {0xFF01, 0xFF60}, {0xFFE0, 0xFFE6},
+	{0x16FE0, 0x16FE4}, {0x16FF0, 0x16FF1}, {0x17000, 0x187F7}, {0x18800, 0x18CD5},
+	{0x18D00, 0x18D08}, {0x1AFF0, 0x1AFF3}, {0x1AFF5, 0x1AFFB}, {0x1AFFD, 0x1AFFE},
+	{0x1B000, 0x1B122}, {0x1B150, 0x1B152}, {0x1B164, 0x1B167}, {0x1B170, 0x1B2FB},
+	{0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A},
+	{0x1F200, 0x1F202}, {0x1F210, 0x1F23B}, {0x1F240, 0x1F248}, {0x1F250, 0x1F251},
+	{0x1F260, 0x1F265}, {0x1F300, 0x1F320}, {0x1F32D, 0x1F335}, {0x1F337, 0x1F37C},
+	{0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA}, {0x1F3CF, 0x1F3D3}, {0x1F3E0, 0x1F3F0},
+	{0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E}, {0x1F440, 0x1F440}, {0x1F442, 0x1F4FC},
+	{0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E}, {0x1F550, 0x1F567}, {0x1F57A, 0x1F57A},
+	{0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4}, {0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5},
+	{0x1F6CC, 0x1F6CC}, {0x1F6D0, 0x1F6D2}, {0x1F6D5, 0x1F6D7}, {0x1F6DD, 0x1F6DF},
+	{0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC}, {0x1F7E0, 0x1F7EB}, {0x1F7F0, 0x1F7F0},
+	{0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1F9FF}, {0x1FA70, 0x1FA74},
+	{0x1FA78, 0x1FA7C}, {0x1FA80, 0x1FA86}, {0x1FA90, 0x1FAAC}, {0x1FAB0, 0x1FABA},
+	{0x1FAC0, 0x1FAC5}, {0x1FAD0, 0x1FAD9}, {0x1FAE0, 0x1FAE7}, {0x1FAF0, 0x1FAF6},
+	{0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+// inRanges 使用二分查找判断字符是否在有序的码位范围中
+func inRanges(r rune, ranges []runeRange) bool {
+	i := sort.Search(len(ranges), func(i int) bool { return ranges[i].last >= r })
+	return i < len(ranges) && ranges[i].first <= r
+}
+
+// isZeroWidth 判断字符是否不占用列：组合字符、格式字符（零宽连接符、零宽空格等）
+// 以及韩文字母的中声和终声（与前面的初声组成一个音节）
+func isZeroWidth(r rune) bool {
+	switch {
+	case r == 0x00AD: // 软连字符显示为连字符
+		return false
+	case r >= 0x1160 && r <= 0x11FF, r >= 0xD7B0 && r <= 0xD7FF:
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf)
+}
+
+// isRegionalIndicator 判断字符是否为区域指示符，两个区域指示符组成一个国旗
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isEmojiModifier 判断字符是否为表情符号的肤色修饰符
+func isEmojiModifier(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+// RuneWidth 返回单个字符在终端中占用的列数：控制字符、组合字符和零宽字符为 0，
+// 东亚宽字符、全角字符、表情符号和区域指示符为 2，其他字符（包括带重音的拉丁字母、西里尔字母、箭头和框线字符）为 1
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7F:
+		return 0
+	case r < 0x7F:
+		return 1
+	case r < 0xA0:
+		return 0
+	case isZeroWidth(r):
+		return 0
+	case inRanges(r, wideRanges), isRegionalIndicator(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// StringWidth 返回字符串在终端中的显示宽度。ANSI 转义序列不占用宽度，
+// 宽度按字素簇（用户感知的一个字符）计算：组合字符附加在前一个字符上，
+// 零宽连接的表情符号序列和国旗只占用第一个字符的宽度
+func StringWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if n := escapeLength(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		size, w := nextGrapheme(s[i:])
+		width += w
+		i += size
+	}
+	return width
+}
+
+// nextGrapheme 返回字符串开头的字素簇的字节长度和显示宽度
+func nextGrapheme(s string) (size, width int) {
+	r, size := utf8.DecodeRuneInString(s)
+	if r < 0x20 || r == 0x7F {
+		return size, 0
+	}
+	width = RuneWidth(r)
+
+	// 两个区域指示符组成一个国旗
+	if isRegionalIndicator(r) {
+		if next, n := utf8.DecodeRuneInString(s[size:]); isRegionalIndicator(next) {
+			return size + n, width
+		}
+		return size, width
+	}
+
+	joined := false // 前一个字符是零宽连接符，后面的字符属于同一个字素簇
+	for size < len(s) {
+		next, n := utf8.DecodeRuneInString(s[size:])
+		switch {
+		case next < 0x20 || next == 0x7F:
+			return size, width
+		case joined, isZeroWidth(next), isEmojiModifier(next):
+			joined = next == 0x200D
+			size += n
+		default:
+			return size, width
+		}
+	}
+	return size, width
+}
+
+// graphemes 将不含转义序列的文本拆分为字素簇，逐字输出的动画使用它避免拆开组合字符和表情符号序列
+func graphemes(s string) []string {
+	var clusters []string
+	for i := 0; i < len(s); {
+		size, _ := nextGrapheme(s[i:])
+		clusters = append(clusters, s[i:i+size])
+		i += size
+	}
+	return clusters
+}
@@ -0,0 +1,355 @@
+package goterm
+
+import (
+	"strings"
+)
+
+// WordBreak 表示换行时超过行宽的单词（例如 URL）的处理方式
+type WordBreak int
+
+const (
+	WordBreakNone   WordBreak = iota // 不拆分，超长的单词单独占一行并超出行宽
+	WordBreakHard                    // 在行宽处直接拆分
+	WordBreakHyphen                  // 在行宽处拆分，并在拆分处添加连字符
+)
+
+// wrapOptions 换行和对齐的设置
+type wrapOptions struct {
+	width     int       // 行宽（包括缩进），小于等于 0 时不换行
+	first     string    // 每个段落第一行的前缀（缩进或列表符号）
+	rest      string    // 段落其余各行的前缀
+	align     Alignment // 对齐方式
+	wordBreak WordBreak // 超长单词的处理方式
+}
+
+// wrapToken 是换行的最小单位：一个单词、一个宽字符或一段不能拆开的文本
+type wrapToken struct {
+	text  string // 文本，包含其中的转义序列
+	width int    // 显示宽度
+	space string // 前面的空白：同一行中原样输出（段落开头的缩进也保留），换行时去掉，两端对齐时可以在这里扩展
+}
+
+// Wrap 将文本按显示宽度换行，原有的换行符、段落开头的缩进和单词之间的空白保留，
+// 宽度小于等于 0 时原样返回。文本中的 ANSI 样式和超链接跨行时在行尾关闭，并在下一行的开头重新开启
+func Wrap(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	return strings.Join(wrapText(text, wrapOptions{width: width}), "\n")
+}
+
+// wrapText 按照设置对文本换行和对齐，返回输出的各行。文本中的每一行作为一个段落
+func wrapText(text string, opts wrapOptions) []string {
+	w := &wrapper{opts: opts}
+	for _, paragraph := range strings.Split(text, "\n") {
+		w.paragraph(tokenize(expandTabs(paragraph)))
+	}
+	return w.lines
+}
+
+// wrapper 保存换行过程中的状态
+type wrapper struct {
+	opts  wrapOptions
+	lines []string // 已经输出的行
+	style string   // 当前行开头生效的样式代码
+	link  string   // 当前行开头生效的超链接地址
+}
+
+// paragraph 使用贪心算法把一个段落的单位排列到各行
+func (w *wrapper) paragraph(tokens []wrapToken) {
+	if len(tokens) == 0 {
+		w.lines = append(w.lines, "")
+		return
+	}
+
+	prefix := w.opts.first
+	limit := w.limit(prefix)
+	var line []wrapToken
+	width := 0
+	flush := func(last bool) {
+		w.emit(prefix, line, limit, last)
+		prefix = w.opts.rest
+		limit = w.limit(prefix)
+		line, width = nil, 0
+	}
+
+	for _, tok := range tokens {
+		gap := len(tok.space)
+		if limit < 0 || width+gap+tok.width <= limit {
+			line = append(line, tok)
+			width += gap + tok.width
+			continue
+		}
+
+		// 超过整行宽度的单词按照设置拆分：先填满当前行的剩余位置，再逐行拆分
+		if w.opts.wordBreak != WordBreakNone && tok.width > w.limit(w.opts.rest) {
+			room := limit - width - gap
+			for tok.width > room {
+				head, tail, ok := w.cut(tok, room)
+				if !ok && len(line) == 0 {
+					// 一个字符都放不下（例如宽度为 1 的行中的宽字符）
+					break
+				}
+				if ok {
+					line = append(line, head)
+					tok = tail
+				}
+				flush(false)
+				tok.space = ""
+				room = limit
+			}
+			line = append(line, tok)
+			width += len(tok.space) + tok.width
+			continue
+		}
+
+		// 放不下时换到下一行，行首不保留空白（段落开头的缩进除外）
+		if len(line) > 0 {
+			flush(false)
+			tok.space = ""
+		}
+		line = append(line, tok)
+		width = len(tok.space) + tok.width
+	}
+	flush(true)
+}
+
+// cut 从单位中拆出显示宽度不超过 room 的前一部分，按照设置在拆分处添加连字符
+func (w *wrapper) cut(tok wrapToken, room int) (head, tail wrapToken, ok bool) {
+	hyphen := w.opts.wordBreak == WordBreakHyphen && room > 1
+	if hyphen {
+		room--
+	}
+	head, tail = splitToken(tok, room)
+	if head.width > room || tail.width == 0 {
+		return tok, wrapToken{}, false
+	}
+	if hyphen {
+		head.text += "-"
+		head.width++
+	}
+	return head, tail, true
+}
+
+// limit 返回使用指定前缀时一行可以容纳的宽度，不换行时返回 -1
+func (w *wrapper) limit(prefix string) int {
+	if w.opts.width <= 0 {
+		return -1
+	}
+	limit := w.opts.width - StringWidth(prefix)
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+// emit 按照对齐方式输出一行，last 表示是否为段落的最后一行
+func (w *wrapper) emit(prefix string, tokens []wrapToken, limit int, last bool) {
+	// 单位之间的间隔，两端对齐时把多余的宽度分配到间隔中
+	gaps := make([]int, len(tokens))
+	width := 0
+	for i, tok := range tokens {
+		gaps[i] = len(tok.space)
+		width += gaps[i] + tok.width
+	}
+	padding := 0
+	if limit >= 0 && width < limit {
+		padding = limit - width
+	}
+
+	var out strings.Builder
+	out.WriteString(prefix)
+	switch w.opts.align {
+	case AlignRight:
+		out.WriteString(strings.Repeat(" ", padding))
+	case AlignCenter:
+		out.WriteString(strings.Repeat(" ", padding/2))
+	case AlignJustify:
+		if !last {
+			justify(tokens, gaps, padding)
+		}
+	}
+
+	// 重新开启上一行结束时生效的样式和超链接
+	out.WriteString(w.style)
+	if w.link != "" {
+		out.WriteString(hyperlinkStart + w.link + hyperlinkEnd)
+	}
+	for i, tok := range tokens {
+		out.WriteString(strings.Repeat(" ", gaps[i]))
+		out.WriteString(tok.text)
+		w.style, w.link = scanEscapes(w.style, w.link, tok.text)
+	}
+	// 在行尾关闭样式和超链接，避免影响到缩进和后面的内容
+	if w.style != "" {
+		out.WriteString(Reset)
+	}
+	if w.link != "" {
+		out.WriteString(hyperlinkStart + hyperlinkEnd)
+	}
+	w.lines = append(w.lines, out.String())
+}
+
+// justify 把 padding 个空格分配到单位之间的间隔中。优先使用原文中的空白，
+// 没有空白时（例如中文）使用所有字符之间的间隔，靠前的间隔多分配一个
+func justify(tokens []wrapToken, gaps []int, padding int) {
+	var slots []int
+	for i := 1; i < len(tokens); i++ {
+		if tokens[i].space != "" {
+			slots = append(slots, i)
+		}
+	}
+	if len(slots) == 0 {
+		for i := 1; i < len(tokens); i++ {
+			slots = append(slots, i)
+		}
+	}
+	if len(slots) == 0 {
+		return
+	}
+	for j, i := range slots {
+		gaps[i] += padding / len(slots)
+		if j < padding%len(slots) {
+			gaps[i]++
+		}
+	}
+}
+
+// scanEscapes 依次应用文本中的转义序列，返回之后生效的样式代码和超链接地址
+func scanEscapes(style, link, text string) (string, string) {
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			style, link = applyEscape(style, link, text[i:i+n])
+			i += n
+			continue
+		}
+		size, _ := nextGrapheme(text[i:])
+		i += size
+	}
+	return style, link
+}
+
+// tokenize 将一行文本拆分为换行的单位：空白分隔的单词，以及可以在前后换行的宽字符（中日韩文字）。
+// 转义序列附加在相邻的单位中，避头标点（如句号、右括号）与前一个单位合并，不会出现在行首
+func tokenize(text string) []wrapToken {
+	var (
+		tokens  []wrapToken
+		word    strings.Builder
+		width   int
+		inWord  bool
+		space   string // 下一个单位前面的空白
+		pending string // 单位之间的转义序列，附加到下一个单位的开头
+	)
+	flush := func() {
+		if inWord {
+			tokens = append(tokens, wrapToken{text: word.String(), width: width, space: space})
+			word.Reset()
+			width, inWord, space = 0, false, ""
+		}
+	}
+
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			if inWord {
+				word.WriteString(text[i : i+n])
+			} else {
+				pending += text[i : i+n]
+			}
+			i += n
+			continue
+		}
+
+		size, w := nextGrapheme(text[i:])
+		cluster := text[i : i+size]
+		i += size
+		if cluster == " " {
+			flush()
+			space += cluster
+			continue
+		}
+
+		switch {
+		case inWord && noBreakBefore(cluster):
+			// 避头标点留在当前单位中
+		case !inWord && space == "" && len(tokens) > 0 && noBreakBefore(cluster):
+			last := tokens[len(tokens)-1]
+			tokens = tokens[:len(tokens)-1]
+			word.WriteString(last.text)
+			width, inWord, space = last.width, true, last.space
+		case w == 2:
+			flush()
+		}
+		inWord = true
+		word.WriteString(pending)
+		pending = ""
+		word.WriteString(cluster)
+		width += w
+		if w == 2 {
+			// 宽字符后面可以换行
+			flush()
+		}
+	}
+	flush()
+
+	if pending != "" {
+		if len(tokens) > 0 {
+			tokens[len(tokens)-1].text += pending
+		} else {
+			tokens = append(tokens, wrapToken{text: pending})
+		}
+	}
+	return tokens
+}
+
+// expandTabs 将制表符展开为空格（制表位间隔 8 列），转义序列不占用列
+func expandTabs(text string) string {
+	if !strings.Contains(text, "\t") {
+		return text
+	}
+	var out strings.Builder
+	column := 0
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			out.WriteString(text[i : i+n])
+			i += n
+			continue
+		}
+		size, w := nextGrapheme(text[i:])
+		if text[i] == '\t' {
+			n := 8 - column%8
+			out.WriteString(strings.Repeat(" ", n))
+			column += n
+		} else {
+			out.WriteString(text[i : i+size])
+			column += w
+		}
+		i += size
+	}
+	return out.String()
+}
+
+// noBreakBefore 判断字符是否为不能出现在行首的标点
+func noBreakBefore(cluster string) bool {
+	return strings.Contains("，。、；：！？）」』】》〉’”…,.;:!?)]}%", cluster)
+}
+
+// splitToken 在指定宽度处拆分单位，前一部分至少包含一个字符。
+// 拆分处之前的转义序列留在前一部分中，样式由 emit 在下一行重新开启
+func splitToken(tok wrapToken, width int) (head, tail wrapToken) {
+	headWidth := 0
+	for i := 0; i < len(tok.text); {
+		if n := escapeLength(tok.text[i:]); n > 0 {
+			i += n
+			continue
+		}
+		size, w := nextGrapheme(tok.text[i:])
+		if headWidth > 0 && headWidth+w > width {
+			head = wrapToken{text: tok.text[:i], width: headWidth, space: tok.space}
+			tail = wrapToken{text: tok.text[i:], width: tok.width - headWidth}
+			return head, tail
+		}
+		headWidth += w
+		i += size
+	}
+	return tok, wrapToken{}
+}
@@ -0,0 +1,25 @@
+package goterm
+
+import "testing"
+
+func TestWrapKeepsWhitespace(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{"no width", "a  b\tc", 0, "a  b\tc"},
+		{"inner runs", "one two  three   four five six", 12, "one two\nthree   four\nfive six"},
+		{"leading indent", "   indented text here", 14, "   indented\ntext here"},
+		{"tabs", "x\tyy\tz", 30, "x       yy      z"},
+		{"each paragraph", "  a b\n  c d", 10, "  a b\n  c d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wrap(tt.text, tt.width); got != tt.want {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+		})
+	}
+}